@@ -19,7 +19,9 @@ import (
 	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis"
 	"github.com/redhat-developer/devconsole-operator/pkg/apis"
 	"github.com/redhat-developer/devconsole-operator/pkg/controller"
+	"github.com/redhat-developer/devconsole-operator/pkg/election"
 	"github.com/redhat-developer/devconsole-operator/version"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -37,6 +39,8 @@ func printVersion() {
 }
 
 func main() {
+	electionOptions := election.DefaultOptions()
+	electionOptions.AddFlags(flag.CommandLine)
 	flag.Parse()
 
 	// The logger instantiated here can be changed to any logger
@@ -60,10 +64,23 @@ func main() {
 	}
 
 	// Become the leader before proceeding
-	err = leader.Become(context.TODO(), "devconsole-operator-lock")
-	if err != nil {
-		log.Error(err, "")
-		os.Exit(1)
+	if electionOptions.Enabled {
+		operatorNamespace, err := k8sutil.GetOperatorNamespace()
+		if err != nil {
+			log.Error(err, "failed to get operator namespace")
+			os.Exit(1)
+		}
+		err = election.Become(context.TODO(), cfg, operatorNamespace, electionOptions)
+		if err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	} else {
+		err = leader.Become(context.TODO(), "devconsole-operator-lock")
+		if err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
 	}
 
 	r := ready.NewFileReady()
@@ -118,6 +135,10 @@ func main() {
 		log.Error(err, "")
 		os.Exit(1)
 	}
+	if err := autoscalingv1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
 
 	// Setup all Controllers
 	if err := controller.AddToManager(mgr); err != nil {
@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// SinkEnvVar is the environment variable holding the audit sink of the operator, either file:///path/to/audit.log
+// or an http(s) URL. Auditing is disabled when it is not set.
+const SinkEnvVar = "AUDIT_SINK"
+
+const (
+	// ActionCreate is recorded when the operator creates a resource.
+	ActionCreate = "create"
+	// ActionUpdate is recorded when the operator updates a resource.
+	ActionUpdate = "update"
+	// ActionDelete is recorded when the operator deletes a resource.
+	ActionDelete = "delete"
+)
+
+// Record is a structured audit record of an action performed by the operator on behalf of a component.
+type Record struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Component string    `json:"component"`
+}
+
+// Sink receives the audit records.
+type Sink interface {
+	Emit(record Record) error
+}
+
+// NewSinkFromEnv returns the sink configured by the AUDIT_SINK environment variable, or nil when it is not set.
+func NewSinkFromEnv() (Sink, error) {
+	value := os.Getenv(SinkEnvVar)
+	if value == "" {
+		return nil, nil
+	}
+	sinkURL, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	switch sinkURL.Scheme {
+	case "file":
+		return NewFileSink(sinkURL.Path), nil
+	case "http", "https":
+		return NewHTTPSink(value), nil
+	}
+	return nil, fmt.Errorf("unsupported audit sink %q, expected a file or http(s) URL", value)
+}
+
+// FileSink appends the audit records as JSON lines to a file.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink returns a sink appending the audit records to the file at the given path.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Emit appends the record to the file.
+func (s *FileSink) Emit(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// HTTPSink posts each audit record as JSON to an endpoint.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink returns a sink posting the audit records to the given URL.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Emit posts the record to the endpoint.
+func (s *HTTPSink) Emit(record Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit sink %s answered %s", s.url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package component
+
+import (
+	"time"
+
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+
+	"github.com/redhat-developer/devconsole-operator/pkg/audit"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// audit emits an audit record for the action performed on obj on behalf of the component. Failing to audit is only
+// logged so that an unavailable sink never blocks reconciling.
+func (r *ReconcileComponent) audit(cp *devconsoleapi.Component, action string, obj runtime.Object) {
+	reqLogger := requestLogger(cp)
+	if r.auditSink == nil {
+		return
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		reqLogger.Error(err, "** failed to audit action **", "Action", action)
+		return
+	}
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		reqLogger.Error(err, "** failed to audit action **", "Action", action)
+		return
+	}
+	record := audit.Record{
+		Time:      time.Now().UTC(),
+		Action:    action,
+		Kind:      gvk.Kind,
+		Namespace: accessor.GetNamespace(),
+		Name:      accessor.GetName(),
+		Component: cp.Name,
+	}
+	if err := r.auditSink.Emit(record); err != nil {
+		reqLogger.Error(err, "** failed to audit action **", "Action", action, "Kind", record.Kind, "Name", record.Name)
+	}
+}
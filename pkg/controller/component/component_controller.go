@@ -4,19 +4,28 @@ import (
 	"context"
 	e "errors"
 	"fmt"
+	"github.com/go-logr/logr"
 	v1 "github.com/openshift/api/apps/v1"
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	buildclientset "github.com/openshift/client-go/build/clientset/versioned/typed/build/v1"
 	imageclientset "github.com/openshift/client-go/image/clientset/versioned/typed/image/v1"
 	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	"github.com/redhat-developer/devconsole-operator/pkg/audit"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"net/http"
+	"os"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -25,9 +34,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+	"time"
 )
 
-var log = logf.Log
+var log logr.Logger = logf.Log
+
+// requestLogger returns the logger of the reconcile request of the given component, whose lines carry its namespace
+// and name as the request does.
+func requestLogger(cp *devconsoleapi.Component) logr.Logger {
+	return log.WithValues("Request.Namespace", cp.Namespace, "Request.Name", cp.Name)
+}
 
 // Add creates a new Component Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
@@ -39,7 +56,17 @@ func Add(mgr manager.Manager) error {
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 	config := mgr.GetConfig()
 	cl, _ := imageclientset.NewForConfig(config)
-	return &ReconcileComponent{client: mgr.GetClient(), scheme: mgr.GetScheme(), imageClient: cl}
+	buildCl, _ := buildclientset.NewForConfig(config)
+	sink, err := audit.NewSinkFromEnv()
+	if err != nil {
+		log.Error(err, "** Invalid audit sink, auditing is disabled **")
+	}
+	limitRequestRatio, err := parseLimitRequestRatio(os.Getenv(limitRequestRatioEnvVar))
+	if err != nil {
+		log.Error(err, "** Invalid limit request ratio, limits and requests are not derived **")
+	}
+	sharedBuilderNamespaces := parseNamespaces(os.Getenv(sharedBuilderNamespacesEnvVar))
+	return &ReconcileComponent{client: mgr.GetClient(), scheme: mgr.GetScheme(), imageClient: cl, buildClient: buildCl, auditSink: sink, recorder: mgr.GetRecorder("component-controller"), registryChecker: dialRegistryChecker{}, dockerfileDetector: rawFileDockerfileDetector{client: http.DefaultClient}, apiServerURL: config.Host, sharedBuilderNamespaces: sharedBuilderNamespaces, limitRequestRatio: limitRequestRatio}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -56,36 +83,141 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	// Watch for changes to secondary resource DeploymentConfig
-	err = c.Watch(&source.Kind{Type: &v1.DeploymentConfig{}}, &handler.EnqueueRequestForObject{})
-	if err != nil {
-		return err
-	}
-
-	// Watch for changes to secondary resource BuildConfig
-	err = c.Watch(&source.Kind{Type: &buildv1.BuildConfig{}}, &handler.EnqueueRequestForObject{})
-	if err != nil {
-		return err
+	// Watch for changes to the secondary resources of the components, so that their manual edits are reconciled back
+	for _, child := range []runtime.Object{&v1.DeploymentConfig{}, &buildv1.BuildConfig{}, &imagev1.ImageStream{}, &corev1.Service{}, &routev1.Route{}, &autoscalingv1.HorizontalPodAutoscaler{}} {
+		err = c.Watch(&source.Kind{Type: child}, ownedByComponent())
+		if err != nil {
+			return err
+		}
 	}
 
-	// Watch for changes to secondary resource Service
-	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForObject{})
+	// Watch for changes to the builds of components, which are named after their BuildConfig
+	err = c.Watch(&source.Kind{Type: &buildv1.Build{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(componentOfBuild),
+	})
 	if err != nil {
 		return err
 	}
 
-	// Watch for changes to secondary resource Route
-	err = c.Watch(&source.Kind{Type: &routev1.Route{}}, &handler.EnqueueRequestForObject{})
+	// Watch for changes to the ConfigMaps holding the git ref of components
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: componentsReferencingGitRef(mgr.GetClient()),
+	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// ownedByComponent enqueues the component controlling the resource of an event. The resources are not always
+// named after their component, e.g. a dedicated builder ImageStream, nor are the ImageStreams of other namespaces
+// and the shared ones owned by any component.
+func ownedByComponent() handler.EventHandler {
+	return &handler.EnqueueRequestForOwner{IsController: true, OwnerType: &devconsoleapi.Component{}}
+}
+
+// limitRequestRatioEnvVar is the environment variable holding the ratio between the limits and the requests of the
+// containers, e.g. 2: the limits of a component only setting requests are derived from them, and vice versa.
+const limitRequestRatioEnvVar = "LIMIT_REQUEST_RATIO"
+
+// sharedBuilderNamespacesEnvVar is the environment variable holding the comma separated namespaces whose builder
+// ImageStreams are shared with the components of the cluster, along with the ones of the openshift namespace.
+const sharedBuilderNamespacesEnvVar = "SHARED_BUILDER_NAMESPACES"
+
 var (
-	_                  reconcile.Reconciler = &ReconcileComponent{}
-	buildTypeImages                         = map[string]string{"nodejs": "nodeshift/centos7-s2i-nodejs:10.x"}
-	openshiftNamespace                      = "openshift"
+	_               reconcile.Reconciler = &ReconcileComponent{}
+	buildTypeImages                      = map[string]string{
+		"nodejs": "nodeshift/centos7-s2i-nodejs:10.x",
+		"java":   "fabric8/s2i-java:latest",
+		"python": "centos/python-36-centos7:latest",
+		"golang": "centos/go-toolset-7-centos7:latest",
+		"dotnet": "registry.access.redhat.com/dotnet/dotnet-22-rhel7:2.2",
+		"ruby":   "centos/ruby-25-centos7:latest",
+	}
+	// endOfLifeBuilderImages are the images of buildTypeImages no longer maintained upstream.
+	endOfLifeBuilderImages = map[string]bool{
+		"nodeshift/centos7-s2i-nodejs:10.x": true,
+	}
+	openshiftNamespace = "openshift"
+	// phaseFailed is the phase of a component whose build type has no builder image.
+	phaseFailed = "Failed"
+	// buildTypeNone deploys the pre-built image of the component, imported by its output ImageStream, without
+	// building it.
+	buildTypeNone = "none"
+	// buildStrategySource builds the component with S2I from its builder image, the default.
+	buildStrategySource = "source"
+	// buildStrategyDocker builds the component from the Dockerfile of its repository.
+	buildStrategyDocker = "docker"
+	// buildStrategyAuto builds the component with the docker strategy when its repository has a Dockerfile, and with
+	// the source one otherwise.
+	buildStrategyAuto = "auto"
+	// imageStreamUpdatePolicyMerge only adds the missing tags to an existing output ImageStream, the default.
+	imageStreamUpdatePolicyMerge = "Merge"
+	// imageStreamUpdatePolicyReplace overwrites the tags of an existing output ImageStream.
+	imageStreamUpdatePolicyReplace = "Replace"
+	// deployStrategyRecreate stops the running pods of the component before starting the new ones, the default.
+	deployStrategyRecreate = "Recreate"
+	// deployStrategyRolling progressively replaces the running pods of the component.
+	deployStrategyRolling = "Rolling"
+	// ownerAnnotation holds the namespace/name of the component an output ImageStream of another namespace belongs to,
+	// as owner references cannot cross namespaces.
+	ownerAnnotation = "devconsole.io/owner"
+	// buildConfigLabel is the label OpenShift sets on builds with the name of their BuildConfig.
+	buildConfigLabel = "openshift.io/build-config.name"
+	// approvedAnnotation grants the deployment of a component requiring an approval.
+	approvedAnnotation = "devconsole.io/approved"
+	// defaultBuildTypeAnnotation is the namespace annotation holding the build type of the components omitting it.
+	defaultBuildTypeAnnotation = "devconsole.io/default-build-type"
+	// defaultPort is the container port of the components which neither set a port nor use a builder image
+	// exposing one.
+	defaultPort int32 = 8080
+	// defaultVolumeSize is the storage requested by the claims created for the volumes of the components.
+	defaultVolumeSize = "1Gi"
+	// livenessInitialDelaySeconds gives the component time to start before it is restarted for failing its liveness
+	// probe.
+	livenessInitialDelaySeconds int32 = 30
+	// webhookSecretKey is the key of the Secret holding the key the webhooks of a BuildConfig are called with.
+	webhookSecretKey = "WebHookSecretKey"
+	// configHashAnnotation holds on the pod template a hash of the configuration of the component's container.
+	configHashAnnotation = "devconsole.io/config-hash"
+	// archNodeLabel is the node label holding the architecture of the node. The stable kubernetes.io/arch label is
+	// not set by the Kubernetes 1.13 nodes of OpenShift.
+	archNodeLabel = "beta.kubernetes.io/arch"
+	// buildTypeResources are the default resources of the build pod per build type, overridden by the component spec
+	buildTypeResources = map[string]corev1.ResourceRequirements{
+		"nodejs": {
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		"java": {
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+	}
+	// missingReferenceRequeueDelay is how long to wait before checking again for missing Secrets or ConfigMaps.
+	missingReferenceRequeueDelay = 15 * time.Second
+	// defaultTargetCPUPercent is the average CPU utilization of its pods the autoscaler of a component aims for,
+	// in percent of their requests, the same as the Kubernetes default.
+	defaultTargetCPUPercent = int32(80)
+	// dependencyRequeueDelay is how long to wait before checking again whether the Services a component depends on
+	// have ready endpoints.
+	dependencyRequeueDelay = 15 * time.Second
+	// approvalRequeueDelay is how long to wait before checking again whether a component has been approved.
+	approvalRequeueDelay = 30 * time.Second
+	// imageResolutionRequeueDelay is how long to wait before checking again whether the output image has been built.
+	imageResolutionRequeueDelay = 30 * time.Second
+	// builderImportRequeueDelay is how long to wait before checking again whether an OpenShift builder ImageStream
+	// has been imported.
+	builderImportRequeueDelay = 15 * time.Second
+	// registryRequeueDelay is how long to wait before checking again whether an unreachable registry is back.
+	registryRequeueDelay = 30 * time.Second
+	// registryCheckTimeout bounds the check that the output registry is reachable.
+	registryCheckTimeout = 5 * time.Second
+	// dockerfileDetectionTimeout is how long to wait for the git hosting service to tell whether the repository of a
+	// component has a Dockerfile.
+	dockerfileDetectionTimeout = 10 * time.Second
+	// defaultDegradedGracePeriod is how long a DeploymentConfig may stay unavailable before the component is Degraded.
+	defaultDegradedGracePeriod = 60 * time.Second
 )
 
 // ReconcileComponent reconciles a Component object
@@ -94,7 +226,27 @@ type ReconcileComponent struct {
 	// that reads objects from the cache and writes to the apiserver
 	client      client.Client
 	imageClient imageclientset.ImageV1Interface
+	buildClient buildclientset.BuildV1Interface
 	scheme      *runtime.Scheme
+	// auditSink receives a record of every change made by the operator, auditing is disabled when nil
+	auditSink audit.Sink
+	// recorder records events on the components, events are not recorded when nil
+	recorder record.EventRecorder
+	// registryChecker checks that the output registry is reachable, the check is skipped when nil
+	registryChecker registryChecker
+	// dockerfileDetector looks for the Dockerfile of the components built with the auto strategy, which are built
+	// with S2I when nil
+	dockerfileDetector dockerfileDetector
+	// apiServerURL is the URL of the API server the webhooks of the BuildConfigs are called on
+	apiServerURL string
+	// now is the clock maintenance windows are checked against, time.Now when nil
+	now func() time.Time
+	// sharedBuilderNamespaces are searched, after the openshift namespace, for the ImageStream of a build type before
+	// one is created in the namespace of a component
+	sharedBuilderNamespaces []string
+	// limitRequestRatio is the ratio between the limits and the requests of the containers enforced by the operator,
+	// read from limitRequestRatioEnvVar when it starts. Resources are left as set on the components when 0.
+	limitRequestRatio float64
 }
 
 // Reconcile reads that state of the cluster for a Component object and makes changes based on the state read
@@ -103,6 +255,7 @@ type ReconcileComponent struct {
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileComponent) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	// Fetch the Component instance
 	cp := &devconsoleapi.Component{}
 	err := r.client.Get(context.TODO(), request.NamespacedName, cp)
@@ -121,21 +274,33 @@ func (r *ReconcileComponent) Reconcile(request reconcile.Request) (reconcile.Res
 	dcList := &v1.DeploymentConfigList{}
 	err = r.ObserveDeploymentConfig(cp, dcList)
 	if err != nil {
-		return reconcile.Result{}, nil
+		return requeueOnConflict(cp, reconcile.Result{}, err)
+	}
+	degradedRecheck, err := r.ObserveDegraded(cp, dcList)
+	if err != nil {
+		return requeueOnConflict(cp, reconcile.Result{}, err)
+	}
+	err = r.ObserveRollouts(cp, dcList)
+	if err != nil {
+		return requeueOnConflict(cp, reconcile.Result{}, err)
 	}
 	bcList := &buildv1.BuildConfigList{}
 	err = r.ObserveBuildConfig(cp, bcList)
 	if err != nil {
-		return reconcile.Result{}, nil
+		return requeueOnConflict(cp, reconcile.Result{}, err)
 	}
 
-	log.Info("============================================================")
-	log.Info(fmt.Sprintf("✨✨ Reconciling Component %s, namespace %s ✨✨", request.Name, request.Namespace))
-	log.Info(fmt.Sprintf("** Creation time: %s", cp.ObjectMeta.CreationTimestamp))
-	log.Info(fmt.Sprintf("** Resource version: %s", cp.ObjectMeta.ResourceVersion))
-	log.Info(fmt.Sprintf("** Generation version: %d", cp.ObjectMeta.Generation))
-	log.Info(fmt.Sprintf("** Deletion time: %s", cp.ObjectMeta.DeletionTimestamp))
-	log.Info("============================================================")
+	reqLogger.Info("============================================================")
+	reqLogger.Info(fmt.Sprintf("✨✨ Reconciling Component %s, namespace %s ✨✨", request.Name, request.Namespace))
+	reqLogger.Info(fmt.Sprintf("** Creation time: %s", cp.ObjectMeta.CreationTimestamp))
+	reqLogger.Info(fmt.Sprintf("** Resource version: %s", cp.ObjectMeta.ResourceVersion))
+	reqLogger.Info(fmt.Sprintf("** Generation version: %d", cp.ObjectMeta.Generation))
+	reqLogger.Info(fmt.Sprintf("** Deletion time: %s", cp.ObjectMeta.DeletionTimestamp))
+	reqLogger.Info("============================================================")
+
+	// drift is detected again on every reconcile, and so are the manifests of a dry run rendered
+	cp.Status.Drift = nil
+	cp.Status.RenderedManifests = nil
 
 	// Assign the generated ResourceVersion to the resource status.
 	if cp.Status.RevNumber == "" {
@@ -143,58 +308,367 @@ func (r *ReconcileComponent) Reconcile(request reconcile.Request) (reconcile.Res
 	}
 
 	if !cp.ObjectMeta.DeletionTimestamp.IsZero() {
-		log.Info("👻👻 Deleting component CR 👻👻")
+		reqLogger.Info("👻👻 Deleting component CR 👻👻")
+		return requeueOnConflict(cp, reconcile.Result{}, r.Finalize(cp))
+	}
+	if cp.Spec.DryRun {
+		err := r.RenderManifests(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
 		return reconcile.Result{}, nil
 	}
+	if !hasFinalizer(cp) {
+		err := r.AddFinalizer(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+	}
+	expired, ttlRecheck, err := r.CheckTTL(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	if expired {
+		return reconcile.Result{}, nil
+	}
+	upToDate, err := r.UpToDate(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	if upToDate {
+		reqLogger.Info("** Skip Reconciling Component: generation already reconciled", "Generation", cp.Generation)
+		result := reconcile.Result{RequeueAfter: degradedRecheck}
+		if ttlRecheck > 0 && (result.RequeueAfter == 0 || ttlRecheck < result.RequeueAfter) {
+			result.RequeueAfter = ttlRecheck
+		}
+		return result, nil
+	}
+	deferred, err := r.CheckMaintenanceWindow(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	if deferred > 0 {
+		return reconcile.Result{RequeueAfter: deferred}, nil
+	}
+	err = validatePorts(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
 
-	gitSource, err := r.GetGitSource(cp)
+	var outputIS, builderIS *imagev1.ImageStream
+	if prebuilt(cp) {
+		// A pre-built image is deployed: there is nothing to build nor any output ImageStream to push to.
+		if err := validatePrebuiltImage(cp); err != nil {
+			reqLogger.Error(err, "** failed to deploy external image **")
+			return r.handleError(cp, err)
+		}
+		reqLogger.Info("** Skip Creating output ImageStream and BuildConfig: deploying external image", "Image", cp.Spec.Image)
+		cp.Status.WebhookURLs = nil
+	} else {
+		err := r.ResolveBuildType(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		gitSource, err := r.GetGitSource(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		err = validateGitURL(gitSource)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		err = validateImageStreamUpdatePolicy(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		outputIS, err = r.CreateOutputImageStream(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		err = r.ValidateBuildType(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		err = validateBuildStrategy(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		err = validateBuildEnv(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		err = r.ResolveBuildStrategy(cp, gitSource)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		builder, createBuilder, err := r.resolveBuilderImageStream(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		builderIS, err = r.CreateBuilderImageStream(cp, builder, createBuilder)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		err = r.ObserveBuilderImageImport(cp, builderIS)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		// the shared ImageStreams may still be importing their images on a fresh cluster, the build would fail
+		// without them
+		if !createBuilder && !tagResolved(builderIS, "latest") && getImageImportFailure(builderIS) == "" {
+			reqLogger.Info("** Waiting for the shared builder ImageStream to be imported **", "ImageStream.Namespace", builderIS.Namespace, "ImageStream.Name", builderIS.Name)
+			return requeueOnConflict(cp, reconcile.Result{RequeueAfter: builderImportRequeueDelay}, r.MarkNotReady(cp, "WaitingForBuilderImage"))
+		}
+		gitSource, err = r.ResolveGitRef(cp, gitSource)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		secret, _ := r.GetSourceSecret(cp, gitSource)
+		reachable, err := r.CheckRegistry(cp, outputIS)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		if !reachable {
+			return requeueOnConflict(cp, reconcile.Result{RequeueAfter: registryRequeueDelay}, r.MarkNotReady(cp, "RegistryUnreachable"))
+		}
+		err = r.CreateWebhookSecret(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		bc, err := r.CreateBuildConfig(cp, builder, gitSource, secret)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+		setCondition(cp, ConditionBuildConfigCreated, corev1.ConditionTrue, "Created", "")
+		cp.Status.WebhookURLs = webhookURLs(bc, r.apiServerURL)
+		err = r.ObserveBuiltCommit(cp, bc)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
+	}
+	err = validateEnv(cp)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.handleError(cp, err)
 	}
-	outputIS, err := r.CreateOutputImageStream(cp)
+	err = validateContainerResources(cp)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.handleError(cp, err)
 	}
-	builderIS, err := r.CreateBuilderImageStream(cp)
+	err = validateVolumes(cp)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.handleError(cp, err)
 	}
-	secret, _ := r.GetSourceSecret(cp, gitSource)
-	_, err = r.CreateBuildConfig(cp, builderIS, gitSource, secret)
+	err = validateReplicas(cp)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.handleError(cp, err)
+	}
+	err = validateDeployStrategy(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	err = validateSelector(cp)
+	if err != nil {
+		return r.handleError(cp, err)
 	}
 	ports, err := r.GetExposedPorts(cp, "latest", builderIS)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.handleError(cp, err)
 	}
-	_, err = r.CreateDeploymentConfig(cp, outputIS, ports)
+	resolved, err := r.ValidateReferences(cp)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.handleError(cp, err)
+	}
+	if !resolved {
+		return requeueOnConflict(cp, reconcile.Result{RequeueAfter: missingReferenceRequeueDelay}, r.MarkNotReady(cp, "MissingReferences"))
+	}
+	approved, err := r.CheckApproval(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	if !approved {
+		return requeueOnConflict(cp, reconcile.Result{RequeueAfter: approvalRequeueDelay}, r.MarkNotReady(cp, "AwaitingApproval"))
+	}
+	dependenciesReady, err := r.CheckDependencies(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	if !dependenciesReady {
+		return requeueOnConflict(cp, reconcile.Result{RequeueAfter: dependencyRequeueDelay}, r.MarkNotReady(cp, "WaitingForDependencies"))
+	}
+	err = r.PinImage(cp, outputIS)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	err = r.CreatePersistentVolumeClaims(cp)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	dc, err := r.CreateDeploymentConfig(cp, outputIS, ports)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	setCondition(cp, ConditionDeploymentCreated, corev1.ConditionTrue, "Created", "")
+	if autoscalingEnabled(cp) {
+		err = r.CreateHorizontalPodAutoscaler(cp)
+		if err != nil {
+			return r.handleError(cp, err)
+		}
 	}
 	_, err = r.CreateService(cp, ports)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.handleError(cp, err)
 	}
 	var route *routev1.Route
-	if cp.Spec.Exposed {
-		route, err = r.CreateRoute(cp)
+	if featureEnabled(cp, featureRoute, cp.Spec.Exposed) {
+		route, err = r.CreateRoute(cp, ports)
 		if err != nil {
-			return reconcile.Result{}, err
+			return r.handleError(cp, err)
 		}
 	}
+	imageResolved, err := r.ObserveOutputImage(cp, outputIS)
+	if err != nil {
+		return r.handleError(cp, err)
+	}
+	if condition := getCondition(cp, ConditionReconcileFailed); condition != nil && condition.Status != corev1.ConditionFalse {
+		setCondition(cp, ConditionReconcileFailed, corev1.ConditionFalse, "Reconciled", "")
+	}
+	cp.Status.Message = readinessSummary(outputIS != nil, imageResolved, dc, route)
+	cp.Status.ObservedGeneration = cp.Generation
+	if imageResolved {
+		setCondition(cp, ConditionReady, corev1.ConditionTrue, "Reconciled", "")
+		err = r.UpdateComponentStatus(cp)
+	} else {
+		err = r.MarkNotReady(cp, "WaitingForBuild")
+	}
+	if err != nil {
+		return r.handleError(cp, err)
+	}
 	if cp.Status.RevNumber == cp.ObjectMeta.ResourceVersion {
-		log.Info(fmt.Sprintf("🎉🎉  Component %s has been successfully created!  🎉🎉 ", cp.Name))
+		reqLogger.Info(fmt.Sprintf("🎉🎉  Component %s has been successfully created!  🎉🎉 ", cp.Name))
 		if route != nil {
-			log.Info(fmt.Sprintf("🎉🎉  Go to http://%s:%d  🎉🎉 ", route.Spec.Host, route.Spec.Port.TargetPort.IntVal))
+			reqLogger.Info(fmt.Sprintf("🎉🎉  Go to http://%s:%d  🎉🎉 ", route.Spec.Host, route.Spec.Port.TargetPort.IntVal))
+		}
+	}
+
+	// an unavailable DeploymentConfig is checked again once its grace period is over, an unresolved output image
+	// until the build pushes it and an ephemeral component once its TTL is over
+	result := reconcile.Result{RequeueAfter: degradedRecheck}
+	if !imageResolved && (result.RequeueAfter == 0 || imageResolutionRequeueDelay < result.RequeueAfter) {
+		result.RequeueAfter = imageResolutionRequeueDelay
+	}
+	if ttlRecheck > 0 && (result.RequeueAfter == 0 || ttlRecheck < result.RequeueAfter) {
+		result.RequeueAfter = ttlRecheck
+	}
+	return result, nil
+}
+
+// UpToDate tells whether the current generation of the component has already been reconciled into a ready
+// component whose resources all exist, in which case reconciling it again, e.g. on the update of its own status,
+// would be redundant. Changes of the annotations of the component are applied along with the next change of its
+// spec, except for components reading their git ref from a ConfigMap, which are always reconciled.
+func (r *ReconcileComponent) UpToDate(cp *devconsoleapi.Component) (bool, error) {
+	if cp.Status.ObservedGeneration != cp.Generation || cp.Spec.GitRefFrom != nil {
+		return false, nil
+	}
+	if ready := getCondition(cp, ConditionReady); ready == nil || ready.Status != corev1.ConditionTrue {
+		return false, nil
+	}
+	children := map[string]runtime.Object{
+		"deploymentconfig": &v1.DeploymentConfig{},
+		"service":          &corev1.Service{},
+	}
+	if !prebuilt(cp) {
+		children["buildconfig"] = &buildv1.BuildConfig{}
+	}
+	if featureEnabled(cp, featureRoute, cp.Spec.Exposed) {
+		children["route"] = &routev1.Route{}
+	}
+	if autoscalingEnabled(cp) {
+		children["horizontalpodautoscaler"] = &autoscalingv1.HorizontalPodAutoscaler{}
+	}
+	for kind, child := range children {
+		if orphaned(cp, kind) {
+			continue
+		}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cp.Namespace, Name: cp.Name}, child)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			requestLogger(cp).Error(err, "** failed to get resource of the component **", "Kind", kind)
+			return false, err
 		}
 	}
+	return true, nil
+}
+
+// ObserveOutputImage tells whether the output tag of the output ImageStream resolves to an image, which is only the
+// case once a build pushed it. The ImageResolved condition reflects whether the DeploymentConfig is still waiting
+// for it. Components deploying a pre-built image have nothing to wait for.
+func (r *ReconcileComponent) ObserveOutputImage(cp *devconsoleapi.Component, outputIS *imagev1.ImageStream) (bool, error) {
+	reqLogger := requestLogger(cp)
+	if outputIS == nil || cp.Spec.Image != "" {
+		return true, nil
+	}
+	is := &imagev1.ImageStream{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: outputIS.Namespace, Name: outputIS.Name}, is)
+	if err != nil && !errors.IsNotFound(err) {
+		reqLogger.Error(err, "** failed to get output ImageStream **")
+		return false, err
+	}
+	resolved := tagResolved(is, outputTag(cp))
+	// the digest pins the image the tag resolves to, which the tag alone does not
+	cp.Status.ImageDigest = tagImage(is, outputTag(cp))
+	status, reason, message := corev1.ConditionTrue, "TagResolved", ""
+	if !resolved {
+		reqLogger.Info("** Waiting for the output image to be built **", "ImageStream.Namespace", outputIS.Namespace, "ImageStream.Name", outputIS.Name)
+		status, reason, message = corev1.ConditionFalse, "WaitingForBuild", fmt.Sprintf("ImageStreamTag %s:%s does not resolve to an image yet", outputIS.Name, outputTag(cp))
+	}
+	if condition := getCondition(cp, ConditionImageResolved); condition != nil && condition.Status == status {
+		return resolved, nil
+	}
+	setCondition(cp, ConditionImageResolved, status, reason, message)
+	return resolved, r.UpdateComponentStatus(cp)
+}
 
-	return reconcile.Result{}, nil
+// ObserveBuiltCommit records the git commit built by the latest completed build of the BuildConfig, so that a running
+// deployment can be traced back to its source.
+func (r *ReconcileComponent) ObserveBuiltCommit(cp *devconsoleapi.Component, bc *buildv1.BuildConfig) error {
+	reqLogger := requestLogger(cp)
+	builds := &buildv1.BuildList{}
+	opts := client.ListOptions{
+		Namespace:     bc.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{buildConfigLabel: bc.Name}),
+	}
+	err := r.client.List(context.TODO(), &opts, builds)
+	if err != nil {
+		reqLogger.Error(err, "** failed to list builds **")
+		return err
+	}
+	var latest *buildv1.Build
+	for i := range builds.Items {
+		build := &builds.Items[i]
+		if build.Status.Phase != buildv1.BuildPhaseComplete || build.Status.CompletionTimestamp == nil {
+			continue
+		}
+		if latest == nil || latest.Status.CompletionTimestamp.Before(build.Status.CompletionTimestamp) {
+			latest = build
+		}
+	}
+	if latest == nil || latest.Spec.Revision == nil || latest.Spec.Revision.Git == nil {
+		return nil
+	}
+	if cp.Status.BuiltCommit != latest.Spec.Revision.Git.Commit {
+		reqLogger.Info("** Built commit of the component changed **", "Build.Name", latest.Name, "Commit", latest.Spec.Revision.Git.Commit)
+		r.lifecycleEvent(cp, corev1.EventTypeNormal, "Built", fmt.Sprintf("Build %s completed at commit %s", latest.Name, latest.Spec.Revision.Git.Commit))
+		cp.Status.BuiltCommit = latest.Spec.Revision.Git.Commit
+	}
+	return nil
 }
 
 // ObserveBuildConfig watches for secondary resource BuildConfig.
 func (r *ReconcileComponent) ObserveBuildConfig(cp *devconsoleapi.Component, bcList *buildv1.BuildConfigList) error {
+	reqLogger := requestLogger(cp)
 	lbls := map[string]string{
 		"app": cp.Name,
 	}
@@ -206,13 +680,13 @@ func (r *ReconcileComponent) ObserveBuildConfig(cp *devconsoleapi.Component, bcL
 		&opts,
 		bcList)
 	if err != nil {
-		log.Error(err, "failed to list existing BuildConfig")
+		reqLogger.Error(err, "failed to list existing BuildConfig")
 		return err
 	}
 
 	for _, bc := range bcList.Items {
 		if bc.Status.LastVersion == 0 {
-			log.Info(fmt.Sprintf("👻👻  Scaling down BuildConfig %s 👻👻", bc.Name))
+			reqLogger.Info(fmt.Sprintf("👻👻  Scaling down BuildConfig %s 👻👻", bc.Name))
 			return r.UpdateStatus(cp, devconsoleapi.PhaseBuilding)
 		}
 	}
@@ -221,6 +695,7 @@ func (r *ReconcileComponent) ObserveBuildConfig(cp *devconsoleapi.Component, bcL
 
 // ObserveDeploymentConfig watches for secondary resource DeploymentConfig.
 func (r *ReconcileComponent) ObserveDeploymentConfig(cp *devconsoleapi.Component, dcList *v1.DeploymentConfigList) error {
+	reqLogger := requestLogger(cp)
 	lbls := map[string]string{
 		"app": cp.Name,
 	}
@@ -232,99 +707,492 @@ func (r *ReconcileComponent) ObserveDeploymentConfig(cp *devconsoleapi.Component
 		&opts,
 		dcList)
 	if err != nil {
-		log.Error(err, "failed to list existing DeploymentConfig")
+		reqLogger.Error(err, "failed to list existing DeploymentConfig")
 		return err
 	}
 
 	for _, dc := range dcList.Items {
 		if dc.Status.Replicas < dc.Spec.Replicas {
-			log.Info(fmt.Sprintf("👻👻  Scaling up DeploymentConfig %s 👻👻", dc.Name))
+			reqLogger.Info(fmt.Sprintf("👻👻  Scaling up DeploymentConfig %s 👻👻", dc.Name))
 			return r.UpdateStatus(cp, devconsoleapi.PhaseDeploying)
 		} else {
-			log.Info(fmt.Sprintf("✨✨ Stable DeploymentConfig %s ✨✨", dc.Name))
+			reqLogger.Info(fmt.Sprintf("✨✨ Stable DeploymentConfig %s ✨✨", dc.Name))
 			return r.UpdateStatus(cp, devconsoleapi.PhaseDeployed)
 		}
 	}
 	return nil
 }
 
+// ObserveDegraded reports the component as Degraded once one of its DeploymentConfigs has been unavailable for
+// longer than the grace period, so that pods restarting as usual do not make the status flap. While within the
+// grace period, it returns how long to wait before checking again.
+func (r *ReconcileComponent) ObserveDegraded(cp *devconsoleapi.Component, dcList *v1.DeploymentConfigList) (time.Duration, error) {
+	var unavailable *v1.DeploymentCondition
+	for i := range dcList.Items {
+		for j := range dcList.Items[i].Status.Conditions {
+			condition := &dcList.Items[i].Status.Conditions[j]
+			if condition.Type != v1.DeploymentAvailable || condition.Status != corev1.ConditionFalse {
+				continue
+			}
+			if unavailable == nil || condition.LastTransitionTime.Before(&unavailable.LastTransitionTime) {
+				unavailable = condition
+			}
+		}
+	}
+	if unavailable == nil && getCondition(cp, ConditionDegraded) == nil {
+		// healthy from the start, nothing worth reporting
+		return 0, nil
+	}
+
+	status, reason, message := corev1.ConditionFalse, "Available", ""
+	var recheck time.Duration
+	if unavailable != nil {
+		gracePeriod := degradedGracePeriod(cp)
+		unavailableFor := time.Since(unavailable.LastTransitionTime.Time)
+		if unavailableFor >= gracePeriod {
+			status, reason = corev1.ConditionTrue, "Unavailable"
+			message = fmt.Sprintf("unavailable since %s: %s", unavailable.LastTransitionTime.UTC().Format(time.RFC3339), unavailable.Message)
+		} else {
+			reason = "WithinGracePeriod"
+			recheck = gracePeriod - unavailableFor
+		}
+	}
+	if condition := getCondition(cp, ConditionDegraded); condition != nil && condition.Status == status && condition.Reason == reason {
+		return recheck, nil
+	}
+	if status == corev1.ConditionTrue {
+		requestLogger(cp).Info(fmt.Sprintf("👻👻  Component %s is degraded 👻👻", cp.Name))
+	}
+	setCondition(cp, ConditionDegraded, status, reason, message)
+	return recheck, r.UpdateComponentStatus(cp)
+}
+
+// CheckTTL deletes an ephemeral component once it has been ready for longer than its TTL, its resources being
+// cleaned up by the finalizer. It tells whether the component expired, or else how long it still has to live.
+func (r *ReconcileComponent) CheckTTL(cp *devconsoleapi.Component) (bool, time.Duration, error) {
+	reqLogger := requestLogger(cp)
+	ready := getCondition(cp, ConditionReady)
+	if cp.Spec.TTLSecondsAfterDeploy == nil || ready == nil || ready.Status != corev1.ConditionTrue {
+		return false, 0, nil
+	}
+	ttl := time.Duration(*cp.Spec.TTLSecondsAfterDeploy) * time.Second
+	if remaining := ttl - time.Since(ready.LastTransitionTime.Time); remaining > 0 {
+		return false, remaining, nil
+	}
+	reqLogger.Info(fmt.Sprintf("👻👻  Component %s expired, deleting it 👻👻", cp.Name))
+	if err := r.client.Delete(context.TODO(), cp); err != nil && !errors.IsNotFound(err) {
+		reqLogger.Error(err, "** Deleting the expired component fails **")
+		return false, 0, err
+	}
+	r.audit(cp, audit.ActionDelete, cp)
+	return true, 0, nil
+}
+
+// degradedGracePeriod returns how long a DeploymentConfig of the component may stay unavailable before the
+// component is reported as Degraded.
+func degradedGracePeriod(cp *devconsoleapi.Component) time.Duration {
+	if cp.Spec.DegradedGracePeriodSeconds != nil {
+		return time.Duration(*cp.Spec.DegradedGracePeriodSeconds) * time.Second
+	}
+	return defaultDegradedGracePeriod
+}
+
+// ObserveRollouts counts the rollouts of the DeploymentConfig of the component which completed successfully. The
+// latest counted version is kept in the status so that each rollout is only counted once.
+func (r *ReconcileComponent) ObserveRollouts(cp *devconsoleapi.Component, dcList *v1.DeploymentConfigList) error {
+	for _, dc := range dcList.Items {
+		if dc.Name != cp.Name || dc.Status.LatestVersion <= cp.Status.LastDeployedVersion || !rolloutComplete(&dc) {
+			continue
+		}
+		requestLogger(cp).Info(fmt.Sprintf("🎉🎉  Rollout %d of DeploymentConfig %s completed  🎉🎉", dc.Status.LatestVersion, dc.Name))
+		r.lifecycleEvent(cp, corev1.EventTypeNormal, "Deployed", fmt.Sprintf("Rollout %d of DeploymentConfig %s completed", dc.Status.LatestVersion, dc.Name))
+		cp.Status.DeployCount++
+		cp.Status.LastDeployedVersion = dc.Status.LatestVersion
+		return r.UpdateComponentStatus(cp)
+	}
+	return nil
+}
+
+// UpdateComponent persists the changes made to the spec and metadata of the component.
+func (r *ReconcileComponent) UpdateComponent(cp *devconsoleapi.Component) error {
+	if err := r.client.Update(context.TODO(), cp); err != nil {
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, cp)
+	return nil
+}
+
+// UpdateComponentStatus persists the changes made to the status of the component through its status subresource.
+// Status writes report on the component rather than act on a resource, hence they are not audited.
+func (r *ReconcileComponent) UpdateComponentStatus(cp *devconsoleapi.Component) error {
+	return r.client.Status().Update(context.TODO(), cp)
+}
+
+// ResolveBuildStrategy records in the status the strategy a component built with the auto strategy is built with:
+// docker when its repository has a Dockerfile in its context directory, source otherwise. The repository is only
+// looked up again once the spec of the component changes.
+func (r *ReconcileComponent) ResolveBuildStrategy(cp *devconsoleapi.Component, gitSource *devconsoleapi.GitSource) error {
+	reqLogger := requestLogger(cp)
+	if cp.Spec.BuildStrategy != buildStrategyAuto {
+		cp.Status.BuildStrategy = ""
+		return nil
+	}
+	if cp.Status.BuildStrategy != "" && cp.Status.ObservedGeneration == cp.Generation {
+		return nil
+	}
+	strategy := buildStrategySource
+	if r.dockerfileDetector != nil {
+		ctx, cancel := context.WithTimeout(context.TODO(), dockerfileDetectionTimeout)
+		defer cancel()
+		found, err := r.dockerfileDetector.HasDockerfile(ctx, gitSource, cp.Spec.ContextDir)
+		if err != nil {
+			reqLogger.Error(err, "** failed to look for the Dockerfile of the component **")
+			return err
+		}
+		if found {
+			strategy = buildStrategyDocker
+		}
+	}
+	reqLogger.Info("** Detected the build strategy of the component **", "BuildStrategy", strategy)
+	cp.Status.BuildStrategy = strategy
+	return nil
+}
+
+// CheckRegistry tells whether the registry the output ImageStream is pushed to is reachable, so that builds are not
+// started only to fail at push time. The check is enabled with the devconsole.io/feature-registry-check: "true"
+// annotation, and skipped until OpenShift sets the repository of the ImageStream.
+func (r *ReconcileComponent) CheckRegistry(cp *devconsoleapi.Component, outputIS *imagev1.ImageStream) (bool, error) {
+	if r.registryChecker == nil || outputIS == nil || !featureEnabled(cp, featureRegistryCheck, false) {
+		return true, nil
+	}
+	host := registryHost(outputIS)
+	if host == "" {
+		return true, nil
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), registryCheckTimeout)
+	defer cancel()
+	if err := r.registryChecker.Check(ctx, host); err != nil {
+		requestLogger(cp).Info("** Output registry is unreachable **", "Registry", host, "Error", err.Error())
+		setCondition(cp, ConditionRegistryReachable, corev1.ConditionFalse, "Unreachable", fmt.Sprintf("registry %s is unreachable: %v", host, err))
+		return false, r.UpdateComponentStatus(cp)
+	}
+	if condition := getCondition(cp, ConditionRegistryReachable); condition != nil && condition.Status == corev1.ConditionTrue {
+		return true, nil
+	}
+	setCondition(cp, ConditionRegistryReachable, corev1.ConditionTrue, "Reachable", "")
+	return true, r.UpdateComponentStatus(cp)
+}
+
+// MarkNotReady sets the Ready condition of the component to false while it waits for the given reason, and
+// persists the conditions set so far.
+func (r *ReconcileComponent) MarkNotReady(cp *devconsoleapi.Component, reason string) error {
+	setCondition(cp, ConditionReady, corev1.ConditionFalse, reason, "")
+	return r.UpdateComponentStatus(cp)
+}
+
 // Update status of component
 func (r *ReconcileComponent) UpdateStatus(cp *devconsoleapi.Component, status string) error {
 	if cp.Status.Phase != status {
 		cp.Status.Phase = status
-		err := r.client.Update(context.TODO(), cp)
+		err := r.UpdateComponentStatus(cp)
 		if err != nil {
-			log.Error(err, "** failed to update component status **")
+			requestLogger(cp).Error(err, "** failed to update component status **")
 			return err
 		}
 	}
 	return nil
 }
 
+// ObserveBuilderImageImport reports on the component whether the builder ImageStream failed to import its image,
+// which would otherwise only show up as a failing build.
+func (r *ReconcileComponent) ObserveBuilderImageImport(cp *devconsoleapi.Component, builderIS *imagev1.ImageStream) error {
+	if message := getImageImportFailure(builderIS); message != "" {
+		requestLogger(cp).Info("** " + message + " **")
+		setCondition(cp, ConditionImageImportFailed, corev1.ConditionTrue, "ImportFailed", message)
+		return r.UpdateComponentStatus(cp)
+	}
+	if condition := getCondition(cp, ConditionImageImportFailed); condition != nil && condition.Status != corev1.ConditionFalse {
+		setCondition(cp, ConditionImageImportFailed, corev1.ConditionFalse, "ImportSucceeded", "")
+		return r.UpdateComponentStatus(cp)
+	}
+	return nil
+}
+
+// ValidateReferences checks that every Secret and ConfigMap referenced by the component's envFrom exists.
+// When one is missing the ReferencesResolved condition is set to false on the component and false is returned,
+// so that the DeploymentConfig is not rolled out with a pod stuck in CreateContainerConfigError.
+func (r *ReconcileComponent) ValidateReferences(cp *devconsoleapi.Component) (bool, error) {
+	for _, envFrom := range cp.Spec.EnvFrom {
+		var kind, name string
+		var obj runtime.Object
+		switch {
+		case envFrom.SecretRef != nil:
+			if envFrom.SecretRef.Optional != nil && *envFrom.SecretRef.Optional {
+				continue
+			}
+			kind, name, obj = "Secret", envFrom.SecretRef.Name, &corev1.Secret{}
+		case envFrom.ConfigMapRef != nil:
+			if envFrom.ConfigMapRef.Optional != nil && *envFrom.ConfigMapRef.Optional {
+				continue
+			}
+			kind, name, obj = "ConfigMap", envFrom.ConfigMapRef.Name, &corev1.ConfigMap{}
+		default:
+			continue
+		}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cp.Namespace}, obj)
+		if errors.IsNotFound(err) {
+			message := fmt.Sprintf("%s %q referenced in envFrom does not exist in namespace %s", kind, name, cp.Namespace)
+			requestLogger(cp).Info("** " + message + " **")
+			setCondition(cp, ConditionReferencesResolved, corev1.ConditionFalse, "Missing"+kind, message)
+			return false, r.UpdateComponentStatus(cp)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	if condition := getCondition(cp, ConditionReferencesResolved); condition != nil && condition.Status != corev1.ConditionTrue {
+		setCondition(cp, ConditionReferencesResolved, corev1.ConditionTrue, "ReferencesFound", "")
+		return true, r.UpdateComponentStatus(cp)
+	}
+	return true, nil
+}
+
+// CheckDependencies tells whether each Service the component waits for has a ready endpoint, so that the component
+// is not deployed before the dependencies it would crash without.
+func (r *ReconcileComponent) CheckDependencies(cp *devconsoleapi.Component) (bool, error) {
+	for _, name := range cp.Spec.WaitForEndpoints {
+		endpoints := &corev1.Endpoints{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cp.Namespace}, endpoints)
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		if err == nil && endpointsReady(endpoints) {
+			continue
+		}
+		message := fmt.Sprintf("waiting for a ready endpoint of Service %s", name)
+		requestLogger(cp).Info("** " + message + " **")
+		if condition := getCondition(cp, ConditionDependenciesReady); condition != nil && condition.Status == corev1.ConditionFalse && condition.Message == message {
+			return false, nil
+		}
+		setCondition(cp, ConditionDependenciesReady, corev1.ConditionFalse, "WaitingForEndpoints", message)
+		return false, r.UpdateComponentStatus(cp)
+	}
+	if condition := getCondition(cp, ConditionDependenciesReady); condition != nil && condition.Status != corev1.ConditionTrue {
+		setCondition(cp, ConditionDependenciesReady, corev1.ConditionTrue, "EndpointsReady", "")
+		return true, r.UpdateComponentStatus(cp)
+	}
+	return true, nil
+}
+
+// CheckApproval tells whether a component requiring an approval has been approved with the devconsole.io/approved
+// annotation. The Approved condition reflects whether the component is still waiting for it.
+func (r *ReconcileComponent) CheckApproval(cp *devconsoleapi.Component) (bool, error) {
+	if !cp.Spec.ApprovalRequired {
+		return true, nil
+	}
+	if cp.Annotations[approvedAnnotation] != "true" {
+		requestLogger(cp).Info("** Component is waiting for approval **")
+		if condition := getCondition(cp, ConditionApproved); condition != nil && condition.Status == corev1.ConditionFalse {
+			return false, nil
+		}
+		setCondition(cp, ConditionApproved, corev1.ConditionFalse, "AwaitingApproval", fmt.Sprintf("waiting for the %s=true annotation", approvedAnnotation))
+		return false, r.UpdateComponentStatus(cp)
+	}
+	if condition := getCondition(cp, ConditionApproved); condition == nil || condition.Status != corev1.ConditionTrue {
+		setCondition(cp, ConditionApproved, corev1.ConditionTrue, "Approved", "")
+		return true, r.UpdateComponentStatus(cp)
+	}
+	return true, nil
+}
+
 // GetGitSource return the GitSource associated to Component CR.
 func (r *ReconcileComponent) GetSourceSecret(cp *devconsoleapi.Component, gitSource *devconsoleapi.GitSource) (*corev1.Secret, error) {
+	reqLogger := requestLogger(cp)
 	// Check if secrets provided exist or not
 	if gitSource.Spec.SecretRef != nil && gitSource.Spec.SecretRef.Name != "" {
 		secret := newSecret(cp, gitSource)
 		foundSecret := &corev1.Secret{}
 		err := r.client.Get(context.TODO(), types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, foundSecret)
 		if err == nil {
-			log.Info("** Secret found ", "Secret.Namespace", foundSecret.Namespace, "Secret.Name", foundSecret.Name)
+			reqLogger.Info("** Secret found ", "Secret.Namespace", foundSecret.Namespace, "Secret.Name", foundSecret.Name)
 			return foundSecret, nil
 		}
 		if errors.IsNotFound(err) {
-			log.Info("** Secret NOT found ", "Secret.Namespace", foundSecret.Namespace, "Secret.Name", foundSecret.Name)
+			reqLogger.Info("** Secret NOT found ", "Secret.Namespace", foundSecret.Namespace, "Secret.Name", foundSecret.Name)
 			return nil, err
 		}
 		return nil, err
 	}
+	// Otherwise optionally fallback to the secret named after the component, if any.
+	if cp.Spec.DetectSourceSecret {
+		foundSecret := &corev1.Secret{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: conventionalSourceSecretName(cp), Namespace: cp.Namespace}, foundSecret)
+		if err == nil {
+			reqLogger.Info("** Conventional source Secret found ", "Secret.Namespace", foundSecret.Namespace, "Secret.Name", foundSecret.Name)
+			return foundSecret, nil
+		}
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
 	return nil, nil
 }
 
-// GetGitSource return the GitSource associated to Component CR.
-func (r *ReconcileComponent) GetGitSource(cp *devconsoleapi.Component) (*devconsoleapi.GitSource, error) {
-	// Validate if codebase is present since this is mandatory field
-	if cp.Spec.GitSourceRef == "" {
-		err := e.New("GitSource reference is not provided")
-		log.Error(err, "** failed to get gitsource **")
-		return nil, err
+// ResolveBuildType defaults the build type of a component which omits it to the one set on its namespace with the
+// devconsole.io/default-build-type annotation. The default is persisted in the spec so that changing the namespace
+// annotation later on does not rebuild existing components.
+func (r *ReconcileComponent) ResolveBuildType(cp *devconsoleapi.Component) error {
+	reqLogger := requestLogger(cp)
+	if cp.Spec.BuildType != "" {
+		return nil
 	}
-	// Get gitsource referenced in component
-	gitSource := &devconsoleapi.GitSource{}
-	err := r.client.Get(context.TODO(), client.ObjectKey{
-		Namespace: cp.Namespace,
-		Name:      cp.Spec.GitSourceRef,
-	}, gitSource)
+	ns := &corev1.Namespace{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: cp.Namespace}, ns)
 	if err != nil {
-		log.Error(err, "** failed to get gitsource **")
-		return nil, err
-	}
-	return gitSource, nil
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		reqLogger.Error(err, "** failed to get namespace **")
+		return err
+	}
+	buildType := ns.Annotations[defaultBuildTypeAnnotation]
+	if buildType == "" {
+		return nil
+	}
+	reqLogger.Info("** Using the default build type of the namespace", "Namespace", cp.Namespace, "BuildType", buildType)
+	cp.Spec.BuildType = buildType
+	return r.UpdateComponent(cp)
+}
+
+// ValidateBuildType checks that a builder image is known for the build type of the component, either an ImageStream
+// of the openshift or a shared builder namespace, or one of buildTypeImages, unless the component sets its own
+// builder image. A missing build type is retried as the namespace may be given a default one later on, whereas an
+// unsupported one requires fixing the component. Only the images of buildTypeImages can be built with another
+// version.
+func (r *ReconcileComponent) ValidateBuildType(cp *devconsoleapi.Component) error {
+	reqLogger := requestLogger(cp)
+	if cp.Spec.BuilderImage != "" {
+		if strings.ContainsAny(cp.Spec.BuilderImage, " \t\n") {
+			err := fmt.Errorf("invalid builder image %q", cp.Spec.BuilderImage)
+			reqLogger.Error(err, "** Invalid builder image **")
+			return withReason("InvalidBuilderImage", newTerminalError(err))
+		}
+		return nil
+	}
+	if cp.Spec.BuildType == "" {
+		err := e.New("no build type is set on the component nor on its namespace")
+		reqLogger.Error(err, "** Invalid build type **")
+		return withReason("MissingBuildType", err)
+	}
+	if cp.Spec.BuildTypeVersion != "" {
+		return validateBuildTypeVersion(cp)
+	}
+	if _, ok := buildTypeImages[cp.Spec.BuildType]; ok {
+		return nil
+	}
+	namespace, err := r.findSharedBuilderImageStream(cp)
+	if err != nil {
+		return err
+	}
+	if namespace != "" {
+		return nil
+	}
+	where := fmt.Sprintf("the %s namespace", openshiftNamespace)
+	if namespaces := r.builderNamespaces(); len(namespaces) > 1 {
+		where = fmt.Sprintf("one of the %s namespaces", strings.Join(namespaces, ", "))
+	}
+	err = fmt.Errorf("unsupported build type %q, supported: %v or an ImageStream of %s", cp.Spec.BuildType, supportedBuildTypes(), where)
+	reqLogger.Error(err, "** Invalid build type **")
+	cp.Status.Phase = phaseFailed
+	return withReason("UnsupportedBuildType", newTerminalError(err))
+}
+
+// ResolveGitRef returns the GitSource to build, whose ref is read from the ConfigMap key referenced by the
+// component's gitRefFrom, if any.
+func (r *ReconcileComponent) ResolveGitRef(cp *devconsoleapi.Component, gitSource *devconsoleapi.GitSource) (*devconsoleapi.GitSource, error) {
+	refFrom := cp.Spec.GitRefFrom
+	if refFrom == nil {
+		return gitSource, nil
+	}
+	cm := &corev1.ConfigMap{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cp.Namespace, Name: refFrom.Name}, cm)
+	if err != nil && !(errors.IsNotFound(err) && refFrom.Optional != nil && *refFrom.Optional) {
+		requestLogger(cp).Error(err, "** failed to get the ConfigMap holding the git ref **")
+		return nil, err
+	}
+	ref, ok := cm.Data[refFrom.Key]
+	if !ok {
+		if refFrom.Optional != nil && *refFrom.Optional {
+			return gitSource, nil
+		}
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "ConfigMap"}, fmt.Sprintf("%s key %s", refFrom.Name, refFrom.Key))
+	}
+	resolved := gitSource.DeepCopy()
+	resolved.Spec.Ref = ref
+	return resolved, nil
+}
+
+// GetGitSource return the GitSource associated to Component CR.
+func (r *ReconcileComponent) GetGitSource(cp *devconsoleapi.Component) (*devconsoleapi.GitSource, error) {
+	reqLogger := requestLogger(cp)
+	// Validate if codebase is present since this is mandatory field
+	if cp.Spec.GitSourceRef == "" {
+		err := newTerminalError(e.New("GitSource reference is not provided"))
+		reqLogger.Error(err, "** failed to get gitsource **")
+		return nil, err
+	}
+	// Get gitsource referenced in component
+	gitSource := &devconsoleapi.GitSource{}
+	err := r.client.Get(context.TODO(), client.ObjectKey{
+		Namespace: cp.Namespace,
+		Name:      cp.Spec.GitSourceRef,
+	}, gitSource)
+	if err != nil {
+		reqLogger.Error(err, "** failed to get gitsource **")
+		return nil, err
+	}
+	return gitSource, nil
 }
 
-// GetExposedPorts returns either the provided port in the component's spec or search for the builder image for exposed port.
+// GetExposedPorts returns either the provided ports in the component's spec or search for the builder image for exposed port.
+// The first port is the one exposed by the Service and probed.
 func (r *ReconcileComponent) GetExposedPorts(cr *devconsoleapi.Component, imageTag string, is *imagev1.ImageStream) ([]corev1.ContainerPort, error) {
-	if cr.Spec.Port != 0 { // port in component's spec overrides exposed port
+	ports := cr.Spec.Ports
+	if len(ports) == 0 && cr.Spec.Port != 0 {
+		ports = []int32{cr.Spec.Port}
+	}
+	if len(ports) > 0 { // ports in component's spec override exposed port
+		containerPorts := make([]corev1.ContainerPort, 0, len(ports))
+		// the ports of the spec are validated before any resource is created
+		for _, port := range ports {
+			containerPorts = append(containerPorts, corev1.ContainerPort{
+				ContainerPort: port,
+				Protocol:      corev1.ProtocolTCP,
+			})
+		}
+		return containerPorts, nil
+	}
+	if is == nil { // no builder image to inspect, fallback to the default port.
 		containerPorts := []corev1.ContainerPort{{
-			ContainerPort: cr.Spec.Port,
+			ContainerPort: defaultPort,
 			Protocol:      corev1.ProtocolTCP,
 		}}
 		return containerPorts, nil
 	}
 	// otherwise extract port from builder docker image.
-	isi, err := r.GetBuilderImageStreamImage("latest", is)
+	isi, err := r.GetBuilderImageStreamImage(cr, "latest", is)
 	if err != nil {
 		return nil, err
 	}
-	ports, err := getExposedPortsFromImageStreamImage(isi)
+	containerPorts, err := getExposedPortsFromImageStreamImage(isi)
 	if err != nil {
 		return nil, err
 	}
-	return ports, nil
+	return containerPorts, nil
 }
 
 // GetBuilderImageStreamImage retrieves exposed port from builder's imagestreamimage.
-func (r *ReconcileComponent) GetBuilderImageStreamImage(imageTag string, is *imagev1.ImageStream) (*imagev1.ImageStreamImage, error) {
+func (r *ReconcileComponent) GetBuilderImageStreamImage(cp *devconsoleapi.Component, imageTag string, is *imagev1.ImageStream) (*imagev1.ImageStreamImage, error) {
 	for _, tag := range is.Status.Tags {
 		if tag.Tag == imageTag {
 			if len(tag.Items) > 0 {
@@ -334,7 +1202,7 @@ func (r *ReconcileComponent) GetBuilderImageStreamImage(imageTag string, is *ima
 				if err != nil {
 					return nil, err
 				}
-				log.Info(fmt.Sprintf("** Found Builder ImageStreamImage %s **", imageStreamImageName))
+				requestLogger(cp).Info(fmt.Sprintf("** Found Builder ImageStreamImage %s **", imageStreamImageName))
 				return imageStreamImage, nil
 			}
 			return nil, fmt.Errorf("unable to find tag %s for image %s", imageTag, is.Name)
@@ -344,25 +1212,39 @@ func (r *ReconcileComponent) GetBuilderImageStreamImage(imageTag string, is *ima
 }
 
 // CreateRoute creates a route to expose the service if CRD's exposed field is true.
-func (r *ReconcileComponent) CreateRoute(cp *devconsoleapi.Component) (*routev1.Route, error) {
-	route := newRoute(cp)
+func (r *ReconcileComponent) CreateRoute(cp *devconsoleapi.Component, containerPorts []corev1.ContainerPort) (*routev1.Route, error) {
+	reqLogger := requestLogger(cp)
+	route := newRoute(cp, containerPorts[0].ContainerPort)
 	if err := controllerutil.SetControllerReference(cp, route, r.scheme); err != nil {
-		log.Error(err, "** Setting owner reference fails **")
+		reqLogger.Error(err, "** Setting owner reference fails **")
 		return nil, err
 	}
 	foundRoute := &routev1.Route{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: route.Name, Namespace: route.Namespace}, foundRoute)
 	if err == nil {
-		log.Info("** Skip Creating Route: Already exist", "Route.Namespace", foundRoute.Namespace, "Route.Name", foundRoute.Name)
-		return foundRoute, nil
+		reqLogger.Info("** Skip Creating Route: Already exist", "Route.Namespace", foundRoute.Namespace, "Route.Name", foundRoute.Name)
+		if err := checkOwnership(cp, foundRoute, "Route"); err != nil {
+			return nil, err
+		}
+		if err := r.ReconcileLabels(cp, foundRoute, route.Labels); err != nil {
+			return nil, err
+		}
+		return foundRoute, r.ReconcileRouteSpec(cp, foundRoute, route)
 	}
 	if errors.IsNotFound(err) {
-		log.Info("💡💡  Creating a new Route  💡💡", "Route.Namespace", route.Namespace, "Route.Name", route.Name)
+		if orphaned(cp, "route") {
+			reqLogger.Info("** Skip Creating Route: orphaned by the user", "Route.Namespace", route.Namespace, "Route.Name", route.Name)
+			return route, nil
+		}
+		reqLogger.Info("💡💡  Creating a new Route  💡💡", "Route.Namespace", route.Namespace, "Route.Name", route.Name)
 		err := r.client.Create(context.TODO(), route)
 		if err != nil && !errors.IsAlreadyExists(err) {
-			log.Error(err, "** CreateRoute creation fails **")
+			reqLogger.Error(err, "** CreateRoute creation fails **")
 			return nil, err
 		}
+		if err == nil {
+			r.audit(cp, audit.ActionCreate, route)
+		}
 		return route, nil
 	}
 	return nil, err
@@ -370,29 +1252,39 @@ func (r *ReconcileComponent) CreateRoute(cp *devconsoleapi.Component) (*routev1.
 
 // CreateService creates a service resource to expose the component S2I deployed image.
 func (r *ReconcileComponent) CreateService(cp *devconsoleapi.Component, containerPorts []corev1.ContainerPort) (*corev1.Service, error) {
+	reqLogger := requestLogger(cp)
 	var port = containerPorts[0].ContainerPort
-	svc, err := newService(cp, port)
-	if err != nil {
-		log.Info("** CreateService: Port is not valid")
-		return nil, err
-	}
+	svc := newService(cp, port)
 	if err := controllerutil.SetControllerReference(cp, svc, r.scheme); err != nil {
-		log.Error(err, "** Setting owner reference fails **")
+		reqLogger.Error(err, "** Setting owner reference fails **")
 		return nil, err
 	}
 	foundSvc := &corev1.Service{}
-	err = r.client.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, foundSvc)
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, foundSvc)
 	if err == nil {
-		log.Info("** Skip Creating Service: Already exist", "Service.Namespace", foundSvc.Namespace, "Service.Name", foundSvc.Name)
-		return foundSvc, nil
+		reqLogger.Info("** Skip Creating Service: Already exist", "Service.Namespace", foundSvc.Namespace, "Service.Name", foundSvc.Name)
+		if err := checkOwnership(cp, foundSvc, "Service"); err != nil {
+			return nil, err
+		}
+		if err := r.ReconcileLabels(cp, foundSvc, svc.Labels); err != nil {
+			return nil, err
+		}
+		return foundSvc, r.ReconcileServiceSpec(cp, foundSvc, svc)
 	}
 	if errors.IsNotFound(err) {
-		log.Info("💡💡  Creating a new Service 💡💡", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
+		if orphaned(cp, "service") {
+			reqLogger.Info("** Skip Creating Service: orphaned by the user", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
+			return svc, nil
+		}
+		reqLogger.Info("💡💡  Creating a new Service 💡💡", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
 		err := r.client.Create(context.TODO(), svc)
 		if err != nil && !errors.IsAlreadyExists(err) {
-			log.Error(err, "** CreateService creation fails **")
+			reqLogger.Error(err, "** CreateService creation fails **")
 			return nil, err
 		}
+		if err == nil {
+			r.audit(cp, audit.ActionCreate, svc)
+		}
 		return svc, nil
 	}
 	return nil, err
@@ -400,116 +1292,820 @@ func (r *ReconcileComponent) CreateService(cp *devconsoleapi.Component, containe
 
 // CreateDeploymentConfig creates a DeploymentConfig OpenShift resource used in S2I.
 func (r *ReconcileComponent) CreateDeploymentConfig(cp *devconsoleapi.Component, outputIS *imagev1.ImageStream, containerPorts []corev1.ContainerPort) (*v1.DeploymentConfig, error) {
-	dc := newDeploymentConfig(cp, outputIS, containerPorts)
+	reqLogger := requestLogger(cp)
+	dc := newDeploymentConfig(cp, outputIS, containerPorts, r.limitRequestRatio)
 	if err := controllerutil.SetControllerReference(cp, dc, r.scheme); err != nil {
-		log.Error(err, "** Setting owner reference fails **")
+		reqLogger.Error(err, "** Setting owner reference fails **")
 		return nil, err
 	}
 	foundDc := &v1.DeploymentConfig{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: dc.Name, Namespace: dc.Namespace}, foundDc)
 	if err == nil {
-		log.Info("** Skip Creating DeploymentConfig: Already exist", "DeploymentConfig.Namespace", foundDc.Namespace, "DeploymentConfig.Name", foundDc.Name)
-		return foundDc, nil
+		reqLogger.Info("** Skip Creating DeploymentConfig: Already exist", "DeploymentConfig.Namespace", foundDc.Namespace, "DeploymentConfig.Name", foundDc.Name)
+		if err := checkOwnership(cp, foundDc, "DeploymentConfig"); err != nil {
+			return nil, err
+		}
+		if err := r.ReconcileLabels(cp, foundDc, dc.Labels); err != nil {
+			return nil, err
+		}
+		if err := r.ReconcileImage(cp, foundDc, dc); err != nil {
+			return nil, err
+		}
+		if err := r.ReconcileTemplate(cp, foundDc, dc); err != nil {
+			return nil, err
+		}
+		return foundDc, r.ReconcileConfig(cp, foundDc, dc)
 	}
 	if errors.IsNotFound(err) {
-		log.Info("💡💡  Creating a new DeploymentConfig 💡💡", "DeploymentConfig.Namespace", dc.Namespace, "DeploymentConfig.Name", dc.Name)
+		if orphaned(cp, "deploymentconfig") {
+			reqLogger.Info("** Skip Creating DeploymentConfig: orphaned by the user", "DeploymentConfig.Namespace", dc.Namespace, "DeploymentConfig.Name", dc.Name)
+			return dc, nil
+		}
+		reqLogger.Info("💡💡  Creating a new DeploymentConfig 💡💡", "DeploymentConfig.Namespace", dc.Namespace, "DeploymentConfig.Name", dc.Name)
 		err := r.client.Create(context.TODO(), dc)
 		if err != nil && !errors.IsAlreadyExists(err) {
-			log.Error(err, "** DeploymentConfig creation fails **")
+			reqLogger.Error(err, "** DeploymentConfig creation fails **")
 			return nil, err
 		}
+		if err == nil {
+			r.audit(cp, audit.ActionCreate, dc)
+			r.event(cp, corev1.EventTypeNormal, "Created", fmt.Sprintf("Created DeploymentConfig %s", dc.Name))
+		}
 		return dc, nil
 	}
 	return nil, err
 }
 
+// PinImage records the image the output tag of the output ImageStream resolves to as the image deployed by a
+// component pinning its deployed image. The image is pinned once, the images built later are not deployed until the
+// component is unpinned.
+func (r *ReconcileComponent) PinImage(cp *devconsoleapi.Component, outputIS *imagev1.ImageStream) error {
+	reqLogger := requestLogger(cp)
+	if !cp.Spec.PinDeployedImage || outputIS == nil || cp.Spec.Image != "" {
+		cp.Status.PinnedImage = ""
+		return nil
+	}
+	if cp.Status.PinnedImage != "" {
+		return nil
+	}
+	is := &imagev1.ImageStream{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: outputIS.Namespace, Name: outputIS.Name}, is)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		reqLogger.Error(err, "** failed to get output ImageStream **")
+		return err
+	}
+	digest := tagImage(is, outputTag(cp))
+	if digest == "" || is.Status.DockerImageRepository == "" {
+		reqLogger.Info("** Waiting for the output image to be built to pin it **", "ImageStream.Namespace", is.Namespace, "ImageStream.Name", is.Name)
+		return nil
+	}
+	cp.Status.PinnedImage = is.Status.DockerImageRepository + "@" + digest
+	reqLogger.Info("** Pinning the deployed image **", "Image", cp.Status.PinnedImage)
+	r.event(cp, corev1.EventTypeNormal, "Pinned", fmt.Sprintf("Pinned the deployed image to %s", cp.Status.PinnedImage))
+	// the pin must outlive a failure of the rest of the reconcile, or a later build could be pinned instead
+	return r.UpdateComponentStatus(cp)
+}
+
+// ReconcileImage makes an existing DeploymentConfig deploy the pinned image of its component, or track the output
+// tag of the output ImageStream again once the component is unpinned or its output tag changed.
+func (r *ReconcileComponent) ReconcileImage(cp *devconsoleapi.Component, dc *v1.DeploymentConfig, desired *v1.DeploymentConfig) error {
+	reqLogger := requestLogger(cp)
+	if dc.Spec.Template == nil {
+		return nil
+	}
+	pinned, tracked := pinnedImage(cp), imageChangeTag(dc)
+	if pinned == "" && (tracked == imageChangeTag(desired) || !hasImageChangeTrigger(desired)) {
+		return nil
+	}
+	desiredContainer := desired.Spec.Template.Spec.Containers[0]
+	var container *corev1.Container
+	for i := range dc.Spec.Template.Spec.Containers {
+		if dc.Spec.Template.Spec.Containers[i].Name == desiredContainer.Name {
+			container = &dc.Spec.Template.Spec.Containers[i]
+		}
+	}
+	if container == nil || (pinned != "" && tracked == "" && container.Image == pinned) {
+		return nil
+	}
+	if r.reportDrift(cp, dc, "image") {
+		return nil
+	}
+	reqLogger.Info("💡💡  Updating the deployed image of DeploymentConfig 💡💡", "DeploymentConfig.Namespace", dc.Namespace, "DeploymentConfig.Name", dc.Name, "Image", desiredContainer.Image)
+	container.Image = desiredContainer.Image
+	dc.Spec.Triggers = desired.Spec.Triggers
+	if err := r.client.Update(context.TODO(), dc); err != nil {
+		reqLogger.Error(err, "** DeploymentConfig update fails **")
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, dc)
+	return nil
+}
+
+// CreatePersistentVolumeClaims creates the missing claims of the volumes of the component. The claims are not owned
+// by the component, so that the data they hold survives its deletion.
+func (r *ReconcileComponent) CreatePersistentVolumeClaims(cp *devconsoleapi.Component) error {
+	reqLogger := requestLogger(cp)
+	for _, volume := range cp.Spec.Volumes {
+		pvc := newPersistentVolumeClaim(cp, volume)
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, &corev1.PersistentVolumeClaim{})
+		if err == nil {
+			reqLogger.Info("** Skip Creating PersistentVolumeClaim: Already exist", "PersistentVolumeClaim.Namespace", pvc.Namespace, "PersistentVolumeClaim.Name", pvc.Name)
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			reqLogger.Error(err, "** failed to get PersistentVolumeClaim **")
+			return err
+		}
+		reqLogger.Info("💡💡  Creating a new PersistentVolumeClaim 💡💡", "PersistentVolumeClaim.Namespace", pvc.Namespace, "PersistentVolumeClaim.Name", pvc.Name)
+		err = r.client.Create(context.TODO(), pvc)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			reqLogger.Error(err, "** PersistentVolumeClaim creation fails **")
+			return err
+		}
+		if err == nil {
+			r.audit(cp, audit.ActionCreate, pvc)
+		}
+	}
+	return nil
+}
+
+// CreateHorizontalPodAutoscaler creates the autoscaler of the DeploymentConfig of the component, or updates the
+// bounds and the CPU target of the existing one.
+func (r *ReconcileComponent) CreateHorizontalPodAutoscaler(cp *devconsoleapi.Component) error {
+	reqLogger := requestLogger(cp)
+	hpa := newHorizontalPodAutoscaler(cp)
+	if err := controllerutil.SetControllerReference(cp, hpa, r.scheme); err != nil {
+		reqLogger.Error(err, "** Setting owner reference fails **")
+		return err
+	}
+	foundHpa := &autoscalingv1.HorizontalPodAutoscaler{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: hpa.Name, Namespace: hpa.Namespace}, foundHpa)
+	if err == nil {
+		reqLogger.Info("** Skip Creating HorizontalPodAutoscaler: Already exist", "HorizontalPodAutoscaler.Namespace", foundHpa.Namespace, "HorizontalPodAutoscaler.Name", foundHpa.Name)
+		if err := checkOwnership(cp, foundHpa, "HorizontalPodAutoscaler"); err != nil {
+			return err
+		}
+		if err := r.ReconcileLabels(cp, foundHpa, hpa.Labels); err != nil {
+			return err
+		}
+		if reflect.DeepEqual(foundHpa.Spec, hpa.Spec) || r.reportDrift(cp, foundHpa, "autoscaling") {
+			return nil
+		}
+		reqLogger.Info("💡💡  Updating the autoscaling of HorizontalPodAutoscaler 💡💡", "HorizontalPodAutoscaler.Namespace", foundHpa.Namespace, "HorizontalPodAutoscaler.Name", foundHpa.Name)
+		foundHpa.Spec = hpa.Spec
+		if err := r.client.Update(context.TODO(), foundHpa); err != nil {
+			reqLogger.Error(err, "** HorizontalPodAutoscaler update fails **")
+			return err
+		}
+		r.audit(cp, audit.ActionUpdate, foundHpa)
+		return nil
+	}
+	if errors.IsNotFound(err) {
+		if orphaned(cp, "horizontalpodautoscaler") {
+			reqLogger.Info("** Skip Creating HorizontalPodAutoscaler: orphaned by the user", "HorizontalPodAutoscaler.Namespace", hpa.Namespace, "HorizontalPodAutoscaler.Name", hpa.Name)
+			return nil
+		}
+		reqLogger.Info("💡💡  Creating a new HorizontalPodAutoscaler 💡💡", "HorizontalPodAutoscaler.Namespace", hpa.Namespace, "HorizontalPodAutoscaler.Name", hpa.Name)
+		err := r.client.Create(context.TODO(), hpa)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			reqLogger.Error(err, "** HorizontalPodAutoscaler creation fails **")
+			return err
+		}
+		if err == nil {
+			r.audit(cp, audit.ActionCreate, hpa)
+		}
+		return nil
+	}
+	return err
+}
+
 // CreateBuildConfig creates a BuildConfig OpenShift resource used in S2I.
-func (r *ReconcileComponent) CreateBuildConfig(cr *devconsoleapi.Component, builderIS *imagev1.ImageStream, gitSource *devconsoleapi.GitSource, secret *corev1.Secret) (*buildv1.BuildConfig, error) {
-	bc := newBuildConfig(cr, builderIS, gitSource, secret)
+func (r *ReconcileComponent) CreateBuildConfig(cr *devconsoleapi.Component, builder corev1.ObjectReference, gitSource *devconsoleapi.GitSource, secret *corev1.Secret) (*buildv1.BuildConfig, error) {
+	reqLogger := requestLogger(cr)
+	bc := newBuildConfig(cr, builder, gitSource, secret)
 	if err := controllerutil.SetControllerReference(cr, bc, r.scheme); err != nil {
-		log.Error(err, "** Setting owner reference fails **")
+		reqLogger.Error(err, "** Setting owner reference fails **")
 		return nil, err
 	}
 	foundBc := &buildv1.BuildConfig{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: bc.Name, Namespace: bc.Namespace}, foundBc)
 	if err == nil {
-		log.Info("** Skip Creating BuildConfig: Already exist", "BuildConfig.Namespace", foundBc.Namespace, "BuildConfig.Name", foundBc.Name)
-		return foundBc, nil
+		reqLogger.Info("** Skip Creating BuildConfig: Already exist", "BuildConfig.Namespace", foundBc.Namespace, "BuildConfig.Name", foundBc.Name)
+		if err := checkOwnership(cr, foundBc, "BuildConfig"); err != nil {
+			return nil, err
+		}
+		if err := r.ReconcileLabels(cr, foundBc, bc.Labels); err != nil {
+			return nil, err
+		}
+		foundBc, err = r.ReconcileBuildTriggers(cr, foundBc, bc.Spec.Triggers)
+		if err != nil {
+			return nil, err
+		}
+		foundBc, err = r.ReconcileBuildSource(cr, foundBc, bc.Spec.Source)
+		if err != nil {
+			return nil, err
+		}
+		foundBc, err = r.ReconcileBuildOutput(cr, foundBc, bc.Spec.Output.To)
+		if err != nil {
+			return nil, err
+		}
+		return r.ReconcileBuildRef(cr, foundBc, bc.Spec.Source.Git.Ref)
 	}
 	if errors.IsNotFound(err) {
-		log.Info("💡💡 Creating a new BuildConfig 💡💡", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name)
+		if orphaned(cr, "buildconfig") {
+			reqLogger.Info("** Skip Creating BuildConfig: orphaned by the user", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name)
+			return bc, nil
+		}
+		reqLogger.Info("💡💡 Creating a new BuildConfig 💡💡", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name)
 		err := r.client.Create(context.TODO(), bc)
 		if err != nil && !errors.IsAlreadyExists(err) {
-			log.Error(err, "** BuildConfig creation fails **")
+			reqLogger.Error(err, "** BuildConfig creation fails **")
 			return nil, err
 		}
+		if err == nil {
+			r.audit(cr, audit.ActionCreate, bc)
+			r.event(cr, corev1.EventTypeNormal, "Created", fmt.Sprintf("Created BuildConfig %s", bc.Name))
+		}
 		return bc, nil
 	}
 	return nil, err
 }
 
+// CreateWebhookSecret creates the Secret securing the webhooks of a component enabling them without referencing a
+// Secret of its own.
+func (r *ReconcileComponent) CreateWebhookSecret(cp *devconsoleapi.Component) error {
+	reqLogger := requestLogger(cp)
+	if !cp.Spec.EnableWebhooks || cp.Spec.WebhookSecretRef != "" {
+		return nil
+	}
+	name := webhookSecretName(cp)
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cp.Namespace}, &corev1.Secret{})
+	if err == nil {
+		reqLogger.Info("** Skip Creating webhook Secret: Already exist", "Secret.Namespace", cp.Namespace, "Secret.Name", name)
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		reqLogger.Error(err, "** failed to get webhook Secret **")
+		return err
+	}
+	secret, err := newWebhookSecret(cp)
+	if err != nil {
+		reqLogger.Error(err, "** Generating the webhook Secret fails **")
+		return err
+	}
+	if err := controllerutil.SetControllerReference(cp, secret, r.scheme); err != nil {
+		reqLogger.Error(err, "** Setting owner reference fails **")
+		return err
+	}
+	reqLogger.Info("💡💡  Creating a new webhook Secret 💡💡", "Secret.Namespace", secret.Namespace, "Secret.Name", secret.Name)
+	err = r.client.Create(context.TODO(), secret)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		reqLogger.Error(err, "** webhook Secret creation fails **")
+		return err
+	}
+	if err == nil {
+		r.audit(cp, audit.ActionCreate, secret)
+		r.event(cp, corev1.EventTypeNormal, "Created", fmt.Sprintf("Created Secret %s", secret.Name))
+	}
+	return nil
+}
+
+// ReconcileConfig updates the environment and the volumes of an existing DeploymentConfig once the configuration
+// of the component changed, as told by the config hash annotation of its pod template. The ConfigChange trigger then
+// rolls the pods out.
+func (r *ReconcileComponent) ReconcileConfig(cp *devconsoleapi.Component, dc *v1.DeploymentConfig, desired *v1.DeploymentConfig) error {
+	reqLogger := requestLogger(cp)
+	hash := desired.Spec.Template.Annotations[configHashAnnotation]
+	if dc.Spec.Template == nil || dc.Spec.Template.Annotations[configHashAnnotation] == hash {
+		return nil
+	}
+	if r.reportDrift(cp, dc, "config") {
+		return nil
+	}
+	desiredContainer := desired.Spec.Template.Spec.Containers[0]
+	for i := range dc.Spec.Template.Spec.Containers {
+		container := &dc.Spec.Template.Spec.Containers[i]
+		if container.Name == desiredContainer.Name {
+			container.Env = desiredContainer.Env
+			container.EnvFrom = desiredContainer.EnvFrom
+			container.VolumeMounts = desiredContainer.VolumeMounts
+		}
+	}
+	dc.Spec.Template.Spec.Volumes = desired.Spec.Template.Spec.Volumes
+	if dc.Spec.Template.Annotations == nil {
+		dc.Spec.Template.Annotations = map[string]string{}
+	}
+	dc.Spec.Template.Annotations[configHashAnnotation] = hash
+	reqLogger.Info("💡💡  Rolling out the new configuration of DeploymentConfig 💡💡", "DeploymentConfig.Namespace", dc.Namespace, "DeploymentConfig.Name", dc.Name)
+	if err := r.client.Update(context.TODO(), dc); err != nil {
+		reqLogger.Error(err, "** DeploymentConfig update fails **")
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, dc)
+	return nil
+}
+
+// ReconcileTemplate updates the replicas, the strategy, the selector and the pod template of an existing
+// DeploymentConfig once they no longer match the component. The images the pod template deploys are left to
+// ReconcileImage, and the replicas of an autoscaled component to the autoscaler.
+func (r *ReconcileComponent) ReconcileTemplate(cp *devconsoleapi.Component, dc *v1.DeploymentConfig, desired *v1.DeploymentConfig) error {
+	reqLogger := requestLogger(cp)
+	if dc.Spec.Template == nil || !deploymentDrifted(cp, dc, desired) {
+		return nil
+	}
+	if r.reportDrift(cp, dc, "pod template") {
+		return nil
+	}
+	dc.Spec.Template = expectedTemplate(dc, desired)
+	dc.Spec.Selector = desired.Spec.Selector
+	dc.Spec.Strategy = desired.Spec.Strategy
+	if !autoscalingEnabled(cp) {
+		dc.Spec.Replicas = desired.Spec.Replicas
+	}
+	reqLogger.Info("💡💡  Updating the pod template of DeploymentConfig 💡💡", "DeploymentConfig.Namespace", dc.Namespace, "DeploymentConfig.Name", dc.Name)
+	if err := r.client.Update(context.TODO(), dc); err != nil {
+		reqLogger.Error(err, "** DeploymentConfig update fails **")
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, dc)
+	return nil
+}
+
+// ReconcileServiceSpec updates the spec of an existing Service once it no longer matches the component, e.g. once
+// its port or its selector changed. The cluster IP of the Service is kept.
+func (r *ReconcileComponent) ReconcileServiceSpec(cp *devconsoleapi.Component, svc *corev1.Service, desired *corev1.Service) error {
+	reqLogger := requestLogger(cp)
+	if !serviceDrifted(svc, desired) || r.reportDrift(cp, svc, "spec") {
+		return nil
+	}
+	reqLogger.Info("💡💡  Updating the spec of Service 💡💡", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
+	clusterIP := svc.Spec.ClusterIP
+	svc.Spec = desired.Spec
+	svc.Spec.ClusterIP = clusterIP
+	if err := r.client.Update(context.TODO(), svc); err != nil {
+		reqLogger.Error(err, "** Service update fails **")
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, svc)
+	return nil
+}
+
+// ReconcileRouteSpec updates the spec of an existing Route once it no longer matches the component, e.g. once its
+// port changed. The host generated for the Route and the TLS configuration it was given are kept.
+func (r *ReconcileComponent) ReconcileRouteSpec(cp *devconsoleapi.Component, route *routev1.Route, desired *routev1.Route) error {
+	reqLogger := requestLogger(cp)
+	if !routeDrifted(route, desired) || r.reportDrift(cp, route, "spec") {
+		return nil
+	}
+	reqLogger.Info("💡💡  Updating the spec of Route 💡💡", "Route.Namespace", route.Namespace, "Route.Name", route.Name)
+	host, tls := route.Spec.Host, route.Spec.TLS
+	route.Spec = desired.Spec
+	if route.Spec.Host == "" {
+		route.Spec.Host = host
+	}
+	if route.Spec.TLS == nil {
+		route.Spec.TLS = tls
+	}
+	if err := r.client.Update(context.TODO(), route); err != nil {
+		reqLogger.Error(err, "** Route update fails **")
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, route)
+	return nil
+}
+
+// ReconcileBuildTriggers sets the triggers of an existing BuildConfig when their types differ from the expected
+// ones, e.g. to pause or resume the builds of the component.
+func (r *ReconcileComponent) ReconcileBuildTriggers(cp *devconsoleapi.Component, bc *buildv1.BuildConfig, triggers []buildv1.BuildTriggerPolicy) (*buildv1.BuildConfig, error) {
+	if reflect.DeepEqual(buildTriggerTypes(bc.Spec.Triggers), buildTriggerTypes(triggers)) {
+		return bc, nil
+	}
+	if r.reportDrift(cp, bc, "triggers") {
+		return bc, nil
+	}
+	requestLogger(cp).Info("💡💡  Updating the triggers of BuildConfig 💡💡", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name, "Paused", cp.Spec.PauseBuilds)
+	bc.Spec.Triggers = triggers
+	if err := r.UpdateBuildConfig(cp, bc); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// UpdateBuildConfig updates an existing BuildConfig. A BuildConfig whose update is rejected as invalid, e.g. for
+// changing a field which cannot be updated in place, is deleted and created again with the desired spec.
+func (r *ReconcileComponent) UpdateBuildConfig(cp *devconsoleapi.Component, bc *buildv1.BuildConfig) error {
+	reqLogger := requestLogger(cp)
+	err := r.client.Update(context.TODO(), bc)
+	if err == nil {
+		r.audit(cp, audit.ActionUpdate, bc)
+		return nil
+	}
+	if !errors.IsInvalid(err) {
+		reqLogger.Error(err, "** BuildConfig update fails **")
+		return err
+	}
+	reqLogger.Info("💡💡  Recreating BuildConfig which cannot be updated in place 💡💡", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name, "Error", err.Error())
+	if err := r.client.Delete(context.TODO(), bc); err != nil && !errors.IsNotFound(err) {
+		reqLogger.Error(err, "** BuildConfig deletion fails **")
+		return err
+	}
+	r.audit(cp, audit.ActionDelete, bc)
+	bc.ResourceVersion = ""
+	bc.UID = ""
+	bc.CreationTimestamp = metav1.Time{}
+	bc.Status = buildv1.BuildConfigStatus{}
+	if err := r.client.Create(context.TODO(), bc); err != nil {
+		reqLogger.Error(err, "** BuildConfig creation fails **")
+		return err
+	}
+	r.audit(cp, audit.ActionCreate, bc)
+	return nil
+}
+
+// ReconcileBuildRef updates the git ref built by an existing BuildConfig and starts a new build from it, unless the
+// BuildConfig is not triggered automatically.
+func (r *ReconcileComponent) ReconcileBuildRef(cp *devconsoleapi.Component, bc *buildv1.BuildConfig, ref string) (*buildv1.BuildConfig, error) {
+	if bc.Spec.Source.Git == nil || bc.Spec.Source.Git.Ref == ref {
+		return bc, nil
+	}
+	if r.reportDrift(cp, bc, "git ref") {
+		return bc, nil
+	}
+	requestLogger(cp).Info("💡💡  Updating the git ref of BuildConfig 💡💡", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name, "Ref", ref)
+	bc.Spec.Source.Git.Ref = ref
+	if err := r.UpdateBuildConfig(cp, bc); err != nil {
+		return nil, err
+	}
+	if err := r.StartBuild(cp, bc, fmt.Sprintf("Git ref changed to %s", ref)); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// ReconcileBuildSource updates the git repository and the directory built by an existing BuildConfig, e.g. once
+// the GitSource of the component points to another repository, and starts a new build from them unless the builds
+// are started on demand.
+func (r *ReconcileComponent) ReconcileBuildSource(cp *devconsoleapi.Component, bc *buildv1.BuildConfig, source buildv1.BuildSource) (*buildv1.BuildConfig, error) {
+	if bc.Spec.Source.Git == nil || (bc.Spec.Source.Git.URI == source.Git.URI && bc.Spec.Source.ContextDir == source.ContextDir) {
+		return bc, nil
+	}
+	if r.reportDrift(cp, bc, "source") {
+		return bc, nil
+	}
+	requestLogger(cp).Info("💡💡  Updating the source of BuildConfig 💡💡", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name, "URI", source.Git.URI, "ContextDir", source.ContextDir)
+	bc.Spec.Source.Git.URI = source.Git.URI
+	bc.Spec.Source.ContextDir = source.ContextDir
+	if err := r.UpdateBuildConfig(cp, bc); err != nil {
+		return nil, err
+	}
+	if err := r.StartBuild(cp, bc, fmt.Sprintf("Git source changed to %s", source.Git.URI)); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// ReconcileBuildOutput updates the ImageStreamTag an existing BuildConfig pushes to once the output tag of the
+// component changed. The image is only pushed to the new tag by the next build.
+func (r *ReconcileComponent) ReconcileBuildOutput(cp *devconsoleapi.Component, bc *buildv1.BuildConfig, to *corev1.ObjectReference) (*buildv1.BuildConfig, error) {
+	if bc.Spec.Output.To == nil || bc.Spec.Output.To.Kind != to.Kind || bc.Spec.Output.To.Name == to.Name {
+		return bc, nil
+	}
+	if r.reportDrift(cp, bc, "output") {
+		return bc, nil
+	}
+	requestLogger(cp).Info("💡💡  Updating the output of BuildConfig 💡💡", "BuildConfig.Namespace", bc.Namespace, "BuildConfig.Name", bc.Name, "Output", to.Name)
+	bc.Spec.Output.To.Name = to.Name
+	if err := r.UpdateBuildConfig(cp, bc); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// StartBuild starts a new build of the BuildConfig, unless its builds are only started on demand. The builds still
+// in flight are cancelled first, as they build a stale source.
+func (r *ReconcileComponent) StartBuild(cp *devconsoleapi.Component, bc *buildv1.BuildConfig, message string) error {
+	if len(bc.Spec.Triggers) == 0 || r.buildClient == nil {
+		return nil
+	}
+	if err := r.CancelRunningBuilds(cp, bc); err != nil {
+		return err
+	}
+	_, err := r.buildClient.BuildConfigs(bc.Namespace).Instantiate(bc.Name, &buildv1.BuildRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: bc.Name},
+		TriggeredBy: []buildv1.BuildTriggerCause{{
+			Message: message,
+		}},
+	})
+	if err != nil {
+		requestLogger(cp).Error(err, "** Starting a new build fails **")
+		return err
+	}
+	return nil
+}
+
+// CancelRunningBuilds cancels the builds of the BuildConfig that are not finished yet.
+func (r *ReconcileComponent) CancelRunningBuilds(cp *devconsoleapi.Component, bc *buildv1.BuildConfig) error {
+	reqLogger := requestLogger(cp)
+	builds := &buildv1.BuildList{}
+	opts := client.ListOptions{
+		Namespace:     bc.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{buildConfigLabel: bc.Name}),
+	}
+	err := r.client.List(context.TODO(), &opts, builds)
+	if err != nil {
+		reqLogger.Error(err, "** failed to list builds **")
+		return err
+	}
+	for i := range builds.Items {
+		build := &builds.Items[i]
+		if !buildInFlight(build) {
+			continue
+		}
+		reqLogger.Info("👻👻  Cancelling stale build 👻👻", "Build.Namespace", build.Namespace, "Build.Name", build.Name)
+		build.Status.Cancelled = true
+		if err := r.client.Update(context.TODO(), build); err != nil {
+			reqLogger.Error(err, "** Cancelling build fails **")
+			return err
+		}
+	}
+	return nil
+}
+
 // CreateOutputImageStream creates an empty image name that holds the source code of the component to build and deploy.
 func (r *ReconcileComponent) CreateOutputImageStream(cp *devconsoleapi.Component) (*imagev1.ImageStream, error) {
+	reqLogger := requestLogger(cp)
 	outputIS := newOutputImageStream(cp)
-	if err := controllerutil.SetControllerReference(cp, outputIS, r.scheme); err != nil {
-		log.Error(err, "** Setting owner reference fails **")
-		return nil, err
+	// owner references cannot cross namespaces, an ImageStream in a shared namespace outlives the component
+	if outputIS.Namespace == cp.Namespace {
+		if err := controllerutil.SetControllerReference(cp, outputIS, r.scheme); err != nil {
+			reqLogger.Error(err, "** Setting owner reference fails **")
+			return nil, err
+		}
 	}
 
 	foundOutputIS := &imagev1.ImageStream{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{Name: outputIS.Name, Namespace: outputIS.Namespace}, foundOutputIS)
 	if err == nil {
-		log.Info("** Skip Creating output ImageStream: Already exist", "ImageStream.Namespace", foundOutputIS.Namespace, "ImageStream.Name", foundOutputIS.Name)
-		return foundOutputIS, nil
+		reqLogger.Info("** Skip Creating output ImageStream: Already exist", "ImageStream.Namespace", foundOutputIS.Namespace, "ImageStream.Name", foundOutputIS.Name)
+		if err := checkOwnership(cp, foundOutputIS, "ImageStream"); err != nil {
+			return nil, err
+		}
+		if err := r.ReconcileLabels(cp, foundOutputIS, outputIS.Labels); err != nil {
+			return nil, err
+		}
+		return r.ReconcileOutputTags(cp, foundOutputIS)
 	}
 	if errors.IsNotFound(err) {
-		log.Info("💡💡  Creating a new output ImageStream 💡💡", "ImageStream.Namespace", outputIS.Namespace, "ImageStream.Name", outputIS.Name)
+		if orphaned(cp, "imagestream") {
+			reqLogger.Info("** Skip Creating output ImageStream: orphaned by the user", "ImageStream.Namespace", outputIS.Namespace, "ImageStream.Name", outputIS.Name)
+			return outputIS, nil
+		}
+		reqLogger.Info("💡💡  Creating a new output ImageStream 💡💡", "ImageStream.Namespace", outputIS.Namespace, "ImageStream.Name", outputIS.Name)
 		err := r.client.Create(context.TODO(), outputIS)
 		if err != nil && !errors.IsAlreadyExists(err) {
-			log.Error(err, "** output ImageStream creation fails **")
+			reqLogger.Error(err, "** output ImageStream creation fails **")
 			return nil, err
 		}
+		if err == nil {
+			r.audit(cp, audit.ActionCreate, outputIS)
+			r.event(cp, corev1.EventTypeNormal, "Created", fmt.Sprintf("Created ImageStream %s", outputIS.Name))
+		}
 		return outputIS, nil
 	}
 	return nil, err
 }
 
-// CreateBuilderImageStream either creates an builder image stream fetch from Docker hub or reuse an existing
-// image stream in OpenShift namespace.
-func (r *ReconcileComponent) CreateBuilderImageStream(cp *devconsoleapi.Component) (*imagev1.ImageStream, error) {
-	var newImageForBuilder *imagev1.ImageStream
-	found := &imagev1.ImageStream{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: cp.Spec.BuildType, Namespace: openshiftNamespace}, found)
-	if err == nil {
-		log.Info("** Skip Creating builder ImageStream: an OpenShift image already exist", "ImageStream.Namespace", found.Namespace, "ImageStream.Name", found.Name)
-		return found, nil
+// ReconcileOutputTags adds the output tags requested by the component which are missing on the existing output
+// ImageStream. Tags which are no longer requested are left untouched as they may still be used by a pipeline, unless
+// the ImageStream update policy of the component is Replace, in which case the tags are overwritten.
+func (r *ReconcileComponent) ReconcileOutputTags(cp *devconsoleapi.Component, outputIS *imagev1.ImageStream) (*imagev1.ImageStream, error) {
+	reqLogger := requestLogger(cp)
+	existing := make(map[string]bool, len(outputIS.Spec.Tags))
+	for _, tag := range outputIS.Spec.Tags {
+		existing[tag.Name] = true
 	}
-	if errors.IsNotFound(err) { // OpenShift builder image is not present, fallback to create one.
-		log.Info(fmt.Sprintf("** Searching in namespace %s imagestream %s fails **", openshiftNamespace, cp.Spec.BuildType))
-		newImageForBuilder = newImageStreamFromDocker(cp)
-		if newImageForBuilder == nil {
-			log.Error(err, "** Creating new BUILDER image fails **")
-			return nil, errors.NewNotFound(schema.GroupResource{Resource: "ImageStream"}, "builder image for build not found")
+	desired := newOutputTags(cp)
+	var missing []imagev1.TagReference
+	for _, tag := range desired {
+		if !existing[tag.Name] {
+			missing = append(missing, tag)
 		}
-		foundBuilderIS := &imagev1.ImageStream{}
-		err := r.client.Get(context.TODO(), types.NamespacedName{Name: newImageForBuilder.Name, Namespace: newImageForBuilder.Namespace}, foundBuilderIS)
+	}
+	replace := cp.Spec.ImageStreamUpdatePolicy == imageStreamUpdatePolicyReplace
+	if len(missing) == 0 && (!replace || len(outputIS.Spec.Tags) == len(desired)) {
+		return outputIS, nil
+	}
+	if r.reportDrift(cp, outputIS, "tags") {
+		return outputIS, nil
+	}
+	if replace {
+		reqLogger.Info("💡💡  Replacing output tags of ImageStream 💡💡", "ImageStream.Namespace", outputIS.Namespace, "ImageStream.Name", outputIS.Name)
+		outputIS.Spec.Tags = desired
+	} else {
+		reqLogger.Info("💡💡  Adding output tags to ImageStream 💡💡", "ImageStream.Namespace", outputIS.Namespace, "ImageStream.Name", outputIS.Name)
+		outputIS.Spec.Tags = append(outputIS.Spec.Tags, missing...)
+	}
+	if err := r.client.Update(context.TODO(), outputIS); err != nil {
+		reqLogger.Error(err, "** output ImageStream tags update fails **")
+		return nil, err
+	}
+	r.audit(cp, audit.ActionUpdate, outputIS)
+	return outputIS, nil
+}
+
+// labeledObject is a resource generated for a component, whose labels are reconciled.
+type labeledObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// ReconcileLabels merges the labels the operator sets on a generated resource onto the existing one, so that
+// labels added to the component later on are propagated. Labels added by others are left untouched. Label sync is
+// disabled with the devconsole.io/feature-label-sync: "false" annotation.
+func (r *ReconcileComponent) ReconcileLabels(cp *devconsoleapi.Component, obj labeledObject, desired map[string]string) error {
+	reqLogger := requestLogger(cp)
+	if !featureEnabled(cp, featureLabelSync, true) {
+		return nil
+	}
+	current := make(map[string]string, len(obj.GetLabels()))
+	for key, value := range obj.GetLabels() {
+		current[key] = value
+	}
+	labels, changed := mergeLabels(current, desired)
+	if !changed || r.reportDrift(cp, obj, "labels") {
+		return nil
+	}
+	obj.SetLabels(labels)
+	reqLogger.Info("** Updating labels of existing resource **", "Namespace", obj.GetNamespace(), "Name", obj.GetName())
+	if err := r.client.Update(context.TODO(), obj); err != nil {
+		reqLogger.Error(err, "** failed to update labels of existing resource **")
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, obj)
+	return nil
+}
+
+// builderNamespaces returns the namespaces whose builder ImageStreams are shared with all the components, the
+// openshift one first.
+func (r *ReconcileComponent) builderNamespaces() []string {
+	return append([]string{openshiftNamespace}, r.sharedBuilderNamespaces...)
+}
+
+// findSharedBuilderImageStream returns the first of the shared builder namespaces holding an ImageStream named
+// after the build type of the component, or an empty string when none does.
+func (r *ReconcileComponent) findSharedBuilderImageStream(cp *devconsoleapi.Component) (string, error) {
+	reqLogger := requestLogger(cp)
+	buildType := cp.Spec.BuildType
+	for _, namespace := range r.builderNamespaces() {
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: buildType, Namespace: namespace}, &imagev1.ImageStream{})
 		if err == nil {
-			log.Info("** Skip Creating builder ImageStream: Already exist", "ImageStream.Namespace", foundBuilderIS.Namespace, "ImageStream.Name", foundBuilderIS.Name)
-			return foundBuilderIS, nil
+			return namespace, nil
 		}
-		if errors.IsNotFound(err) {
-			log.Info("** 💡💡 Creating a new builder ImageStream 💡💡", "ImageStream.Namespace", newImageForBuilder.Namespace, "ImageStream.Name", newImageForBuilder.Name)
-			err := r.client.Create(context.TODO(), newImageForBuilder)
-			if err != nil && !errors.IsAlreadyExists(err) {
-				log.Error(err, "** builder ImageStream creation fails **")
-				return nil, err
-			}
-			if err := controllerutil.SetControllerReference(cp, newImageForBuilder, r.scheme); err != nil {
-				log.Error(err, "** Setting owner reference fails **")
-				return nil, err
-			}
-			return foundBuilderIS, nil
+		if !errors.IsNotFound(err) {
+			reqLogger.Error(err, "** failed to get shared builder ImageStream **", "ImageStream.Namespace", namespace)
+			return "", err
+		}
+		reqLogger.Info(fmt.Sprintf("** Searching in namespace %s imagestream %s fails **", namespace, buildType))
+	}
+	return "", nil
+}
+
+// resolveBuilderImageStream returns the ImageStreamTag the component is built from, and whether its ImageStream
+// must be created: an existing ImageStream of the OpenShift namespace, or else of a shared builder namespace, is
+// used as is, otherwise one importing the builder image of the component, or else the one of its build type, is
+// created in the namespace of the component.
+func (r *ReconcileComponent) resolveBuilderImageStream(cp *devconsoleapi.Component) (corev1.ObjectReference, bool, error) {
+	// the builder image or version of the component overrides the shared one of its build type
+	if cp.Spec.BuilderImage == "" && cp.Spec.BuildTypeVersion == "" {
+		namespace, err := r.findSharedBuilderImageStream(cp)
+		if err != nil {
+			return corev1.ObjectReference{}, false, err
+		}
+		if namespace != "" {
+			return builderImageStreamTag(namespace, cp.Spec.BuildType), false, nil
+		}
+		// no shared builder image is present, fallback to create one.
+	}
+	builder := newImageStreamFromDocker(cp)
+	if builder == nil {
+		// the ImageStream of the build type may have been removed from the openshift namespace since it was validated
+		err := fmt.Errorf("no builder image found for build type %q", cp.Spec.BuildType)
+		requestLogger(cp).Error(err, "** Creating new BUILDER image fails **")
+		cp.Status.Phase = phaseFailed
+		return corev1.ObjectReference{}, false, withReason("UnsupportedBuildType", err)
+	}
+	return builderImageStreamTag(builder.Namespace, builder.Name), true, nil
+}
+
+// builderImageStreamTag returns the reference to the latest tag of the given builder ImageStream.
+func builderImageStreamTag(namespace, name string) corev1.ObjectReference {
+	return corev1.ObjectReference{Kind: "ImageStreamTag", Namespace: namespace, Name: name + ":latest"}
+}
+
+// CreateBuilderImageStream either creates the builder ImageStream resolved by resolveBuilderImageStream, importing
+// its image from Docker hub, or returns the existing one of the OpenShift namespace.
+func (r *ReconcileComponent) CreateBuilderImageStream(cp *devconsoleapi.Component, builder corev1.ObjectReference, create bool) (*imagev1.ImageStream, error) {
+	reqLogger := requestLogger(cp)
+	if !create {
+		found := &imagev1.ImageStream{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: strings.TrimSuffix(builder.Name, ":latest"), Namespace: builder.Namespace}, found)
+		if err != nil {
+			reqLogger.Error(err, "** failed to get OpenShift builder ImageStream **")
+			return nil, err
 		}
+		reqLogger.Info("** Skip Creating builder ImageStream: an OpenShift image already exist", "ImageStream.Namespace", found.Namespace, "ImageStream.Name", found.Name)
+		return found, nil
+	}
+	if image := builderImage(cp); cp.Spec.BuilderImage == "" && endOfLifeBuilderImages[image] {
+		r.event(cp, corev1.EventTypeWarning, "DeprecatedBuilderImage", fmt.Sprintf("the default builder image %s of build type %s reached its end of life, set a builderImage to upgrade", image, cp.Spec.BuildType))
+	}
+	newImageForBuilder := newImageStreamFromDocker(cp)
+	shared := sharedBuilderImageStream(cp)
+	foundBuilderIS := &imagev1.ImageStream{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: newImageForBuilder.Name, Namespace: newImageForBuilder.Namespace}, foundBuilderIS)
+	if err == nil {
+		reqLogger.Info("** Skip Creating builder ImageStream: Already exist", "ImageStream.Namespace", foundBuilderIS.Namespace, "ImageStream.Name", foundBuilderIS.Name)
+		if shared {
+			return foundBuilderIS, r.DisownSharedImageStream(cp, foundBuilderIS)
+		}
+		return foundBuilderIS, r.ReconcileLabels(cp, foundBuilderIS, newImageForBuilder.Labels)
+	}
+	if !errors.IsNotFound(err) {
+		reqLogger.Error(err, "** failed to get builder ImageStream **")
+		return nil, err
+	}
+	// a shared builder ImageStream must not be garbage collected along with the component which happened to create it
+	if !shared {
+		if err := controllerutil.SetControllerReference(cp, newImageForBuilder, r.scheme); err != nil {
+			reqLogger.Error(err, "** Setting owner reference fails **")
+			return nil, err
+		}
+	}
+	reqLogger.Info("** 💡💡 Creating a new builder ImageStream 💡💡", "ImageStream.Namespace", newImageForBuilder.Namespace, "ImageStream.Name", newImageForBuilder.Name)
+	err = r.client.Create(context.TODO(), newImageForBuilder)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		reqLogger.Error(err, "** builder ImageStream creation fails **")
+		return nil, err
+	}
+	if err == nil {
+		r.audit(cp, audit.ActionCreate, newImageForBuilder)
+		r.event(cp, corev1.EventTypeNormal, "Created", fmt.Sprintf("Created ImageStream %s", newImageForBuilder.Name))
 	}
 	return newImageForBuilder, nil
 }
+
+// DisownSharedImageStream removes the owner references to components from a shared builder ImageStream, which
+// previous versions of the operator set on creation, so that it outlives the component which created it.
+func (r *ReconcileComponent) DisownSharedImageStream(cp *devconsoleapi.Component, is *imagev1.ImageStream) error {
+	reqLogger := requestLogger(cp)
+	var owners []metav1.OwnerReference
+	for _, owner := range is.OwnerReferences {
+		if owner.APIVersion != devconsoleapi.SchemeGroupVersion.String() || owner.Kind != "Component" {
+			owners = append(owners, owner)
+		}
+	}
+	if len(owners) == len(is.OwnerReferences) {
+		return nil
+	}
+	reqLogger.Info("** Removing the component owners of the shared builder ImageStream **", "ImageStream.Namespace", is.Namespace, "ImageStream.Name", is.Name)
+	is.OwnerReferences = owners
+	if err := r.client.Update(context.TODO(), is); err != nil {
+		reqLogger.Error(err, "** failed to update owners of shared builder ImageStream **")
+		return err
+	}
+	r.audit(cp, audit.ActionUpdate, is)
+	return nil
+}
+
+// componentOfBuild maps a build to the component named after its BuildConfig.
+func componentOfBuild(obj handler.MapObject) []reconcile.Request {
+	name, ok := obj.Meta.GetLabels()[buildConfigLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: name}}}
+}
+
+// componentsReferencingGitRef maps a ConfigMap to the components of its namespace which read their git ref from it.
+func componentsReferencingGitRef(cl client.Client) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		components := &devconsoleapi.ComponentList{}
+		err := cl.List(context.TODO(), &client.ListOptions{Namespace: obj.Meta.GetNamespace()}, components)
+		if err != nil {
+			log.Error(err, "** failed to list components referencing ConfigMap **", "ConfigMap.Name", obj.Meta.GetName())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cp := range components.Items {
+			if cp.Spec.GitRefFrom != nil && cp.Spec.GitRefFrom.Name == obj.Meta.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: cp.Namespace, Name: cp.Name},
+				})
+			}
+		}
+		return requests
+	}
+}
@@ -2,7 +2,11 @@ package component
 
 import (
 	"context"
+	e "errors"
 	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
 
 	appsv1 "github.com/openshift/api/apps/v1"
 	buildv1 "github.com/openshift/api/build/v1"
@@ -11,25 +15,38 @@ import (
 
 	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
 
+	"github.com/redhat-developer/devconsole-operator/pkg/audit"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 
 	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 
 	"fmt"
 
 	dockerapiv10 "github.com/openshift/api/image/docker10"
+	fakebuild "github.com/openshift/client-go/build/clientset/versioned/fake"
 	fakeimage "github.com/openshift/client-go/image/clientset/versioned/fake"
 )
 
@@ -94,6 +111,7 @@ func TestComponentController(t *testing.T) {
 	// Register operator types with the runtime scheme.
 	s := scheme.Scheme
 	s.AddKnownTypes(devconsoleapi.SchemeGroupVersion, cp)
+	s.AddKnownTypes(devconsoleapi.SchemeGroupVersion, &devconsoleapi.ComponentList{})
 	s.AddKnownTypes(devconsoleapi.SchemeGroupVersion, gs)
 	s.AddKnownTypes(corev1.SchemeGroupVersion, secret)
 
@@ -144,6 +162,10 @@ func TestComponentController(t *testing.T) {
 		instance := &devconsoleapi.Component{}
 		errGet := r.client.Get(context.TODO(), req.NamespacedName, instance)
 		require.NoError(t, errGet, "component is not created")
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionBuildConfigCreated).Status, "build config should be reported as created")
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionDeploymentCreated).Status, "deployment config should be reported as created")
+		require.Equal(t, corev1.ConditionFalse, getCondition(instance, ConditionReady).Status, "component should not be ready before its image is built")
+		require.Equal(t, "WaitingForBuild", getCondition(instance, ConditionReady).Reason, "component should be waiting for its build")
 
 		is := &imagev1.ImageStream{}
 		errGetImage := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is)
@@ -154,20 +176,13 @@ func TestComponentController(t *testing.T) {
 		require.NoError(t, errGetBuilderImage, "builder imagestream is not created")
 		require.Equal(t, cp.Spec.BuildType, isBuilder.ObjectMeta.Name, "imagestream builder should be named after component's buildtype")
 		require.Equal(t, Namespace, isBuilder.ObjectMeta.Namespace, "")
-		require.Equal(t, 7, len(isBuilder.Labels), "imagestream builder should contain seven labels")
-		require.Equal(t, Name, isBuilder.Labels["app"], "imagestream builder should have a label with app of CR")
-		require.Equal(t, "application-1", isBuilder.Labels["app.kubernetes.io/part-of"], "isBuilder builder should have a label for part-of of CR")
-		require.Equal(t, "MyComp", isBuilder.Labels["app.kubernetes.io/name"], "isBuilder builder should have a label with name of CR")
-		require.Equal(t, "backend", isBuilder.Labels["app.kubernetes.io/component"], "isBuilder builder should have a label with component of CR")
-		require.Equal(t, "mycomp-1", isBuilder.Labels["app.kubernetes.io/instance"], "isBuilder builder should have a label with instance of CR")
-		require.Equal(t, "1.0", isBuilder.Labels["app.kubernetes.io/version"], "isBuilder builder should have a label with version of CR")
+		require.Empty(t, isBuilder.Labels, "imagestream builder is shared by the components of the buildtype and should not carry the labels of one")
+		require.Empty(t, isBuilder.OwnerReferences, "imagestream builder is shared by the components of the buildtype and should not be owned by one")
 		require.Equal(t, 1, len(isBuilder.Spec.Tags), "imagestream builder should have a tag specified when")
 		require.Equal(t, "latest", isBuilder.Spec.Tags[0].Name, "imagestream builder should take latest version")
 		require.Equal(t, "DockerImage", isBuilder.Spec.Tags[0].From.Kind, "imagestream builder should be taken from docker when not found in cluster")
 		require.Equal(t, "nodeshift/centos7-s2i-nodejs:10.x", isBuilder.Spec.Tags[0].From.Name, "imagestream builder should be taken from nodeshift/centos7-s2i-nodejs:10.x")
-		require.Equal(t, 2, len(isBuilder.Annotations), "imagestream builder should contain two annotations")
-		require.Equal(t, "https://github.com/test/example", isBuilder.Annotations["app.openshift.io/vcs-uri"], "imagestream builder should have a annotation with vsc-uri of CR")
-		require.Equal(t, "master", isBuilder.Annotations["app.openshift.io/vcs-ref"], "imagestream builder should have an annotation with vcs-ref of CR")
+		require.Empty(t, isBuilder.Annotations, "imagestream builder is shared by the components of the buildtype and should not carry the annotations of one")
 
 		bc := &buildv1.BuildConfig{}
 		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc)
@@ -310,10 +325,14 @@ func TestComponentController(t *testing.T) {
 		}
 
 		//when
-		_, err := r.Reconcile(req)
+		res, err := r.Reconcile(req)
 
 		//then
-		require.Error(t, err, "reconcile should failing")
+		require.NoError(t, err, "invalid port should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "invalid port should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "InvalidPort", getCondition(instance, ConditionReady).Reason, "invalid port should be reported")
 	})
 
 	t.Run("with ReconcileComponent CR containing all required field and buildtype matches openshift namespace imagestream", func(t *testing.T) {
@@ -324,6 +343,14 @@ func TestComponentController(t *testing.T) {
 				Namespace: "openshift",
 			},
 			Spec: imagev1.ImageStreamSpec{},
+			Status: imagev1.ImageStreamStatus{
+				Tags: []imagev1.NamedTagEventList{{
+					Tag: "latest",
+					Items: []imagev1.TagEvent{{
+						Image: "sha256:9579a93ee",
+					}},
+				}},
+			},
 		}
 		// Objects to track in the fake client.
 		objs := []runtime.Object{
@@ -510,9 +537,10 @@ func TestComponentController(t *testing.T) {
 		cp.Spec.GitSourceRef = "my-git-source"
 		// Create a fake client to mock API calls.
 		cl := fake.NewFakeClient(objs...)
+		recorder := record.NewFakeRecorder(10)
 
 		// Create a ReconcileComponent object with the scheme and fake client.
-		r := &ReconcileComponent{client: cl, scheme: s}
+		r := &ReconcileComponent{client: cl, scheme: s, recorder: recorder}
 
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
@@ -530,6 +558,12 @@ func TestComponentController(t *testing.T) {
 		instance := &devconsoleapi.Component{}
 		errGet := r.client.Get(context.TODO(), req.NamespacedName, instance)
 		require.NoError(t, errGet, "component is not created")
+		ready := getCondition(instance, ConditionReady)
+		require.NotNil(t, ready, "readiness should be reported")
+		require.Equal(t, corev1.ConditionFalse, ready.Status, "component without buildtype should not be ready")
+		require.Equal(t, "MissingBuildType", ready.Reason, "missing buildtype should be reported")
+		require.Contains(t, recordedEvents(recorder), "Warning MissingBuildType no build type is set on the component nor on its namespace", "missing buildtype should be recorded as an event")
+		require.Nil(t, getCondition(instance, ConditionBuildConfigCreated), "build config should not be reported as created")
 
 		is := &imagev1.ImageStream{}
 		errGetImage := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is)
@@ -566,14 +600,19 @@ func TestComponentController(t *testing.T) {
 		}
 
 		//when
-		_, err := r.Reconcile(req)
+		res, err := r.Reconcile(req)
 
 		//then
-		require.Error(t, err, "reconcile should fail since no gitsource reference provided")
+		require.NoError(t, err, "reconcile should not be retried since no gitsource reference provided")
+		require.False(t, res.Requeue, "reconcile should not requeue a terminal error")
 
 		instance := &devconsoleapi.Component{}
 		errGet := r.client.Get(context.TODO(), req.NamespacedName, instance)
 		require.NoError(t, errGet, "component is not created")
+		condition := getCondition(instance, ConditionReconcileFailed)
+		require.NotNil(t, condition, "reconcile failure should be recorded")
+		require.Equal(t, corev1.ConditionTrue, condition.Status, "reconcile failure should be recorded")
+		require.Equal(t, "TerminalError", condition.Reason, "missing gitsource reference is a terminal error")
 
 		is := &imagev1.ImageStream{}
 		errGetImage := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is)
@@ -646,35 +685,4570 @@ func TestComponentController(t *testing.T) {
 		require.Equal(t, dc.Spec.Template.Spec.Containers[0].Ports[0].Name, "8080-tcp")
 
 	})
-}
 
-func fakeImageStreamImage(imageName string, ports []string, containerConfig string) *imagev1.ImageStreamImage {
-	exposedPorts := make(map[string]struct{})
-	var s struct{}
-	for _, port := range ports {
-		exposedPorts[port] = s
-	}
-	builderImage := &imagev1.ImageStreamImage{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s@sha256:9579a93ee", imageName),
-			Namespace: "openshift",
-		},
-		Image: imagev1.Image{
+	t.Run("with ReconcileComponent CR referencing a missing secret in envFrom", func(t *testing.T) {
+		//given
+		cpEnvFrom := &devconsoleapi.Component{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "sha256:9579a93ee",
+				Name:      Name,
+				Namespace: Namespace,
 			},
-			DockerImageMetadata: runtime.RawExtension{
-				Object: &dockerapiv10.DockerImage{
-					ContainerConfig: dockerapiv10.DockerConfig{
-						ExposedPorts: exposedPorts,
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "missing-secret"},
 					},
-				},
+				}},
 			},
-			DockerImageReference: fmt.Sprintf("docker.io/centos/%s-36-centos7@sha256:9579a93ee", imageName),
-		},
-	}
-	if containerConfig != "" {
-		(*builderImage).Image.DockerImageMetadata.Raw = []byte(containerConfig)
-	}
-	return builderImage
+		}
+		objs := []runtime.Object{
+			gs,
+			cpEnvFrom,
+			newTestResolvedOutputImageStream(),
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(objs...)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile should not fail on a missing reference")
+		require.Equal(t, missingReferenceRequeueDelay, res.RequeueAfter, "reconcile should requeue until the secret exists")
+
+		instance := &devconsoleapi.Component{}
+		errGet := cl.Get(context.TODO(), req.NamespacedName, instance)
+		require.NoError(t, errGet, "component is not created")
+		condition := getCondition(instance, ConditionReferencesResolved)
+		require.NotNil(t, condition, "component should report the ReferencesResolved condition")
+		require.Equal(t, corev1.ConditionFalse, condition.Status)
+		require.Equal(t, "MissingSecret", condition.Reason)
+		require.Contains(t, condition.Message, `Secret "missing-secret"`)
+		require.Equal(t, "MissingReferences", getCondition(instance, ConditionReady).Reason, "component should not be ready while a reference is missing")
+
+		dc := &appsv1.DeploymentConfig{}
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+		require.Error(t, errGetDC, "deployment config should not be created while a reference is missing")
+
+		// once the secret exists, the deployment config is created with the envFrom reference
+		missingSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "missing-secret",
+				Namespace: Namespace,
+			},
+		}
+		require.NoError(t, cl.Create(context.TODO(), missingSecret))
+
+		res, err = r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, reconcile.Result{}, res, "reconcile should not requeue once references are resolved")
+
+		errGetDC = cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+		require.NoError(t, errGetDC, "deployment config is not created")
+		require.Equal(t, "missing-secret", dc.Spec.Template.Spec.Containers[0].EnvFrom[0].SecretRef.Name)
+
+		errGet = cl.Get(context.TODO(), req.NamespacedName, instance)
+		require.NoError(t, errGet, "component is not created")
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionReferencesResolved).Status)
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionReady).Status, "component should be ready once references are resolved")
+	})
+
+	t.Run("with ReconcileComponent CR skipping the output imagestream", func(t *testing.T) {
+		//given
+		cpExternal := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				Image:                 "quay.io/example/myapp:1.0",
+				SkipOutputImageStream: true,
+			},
+		}
+		objs := []runtime.Object{
+			cpExternal,
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(objs...)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+
+		is := &imagev1.ImageStream{}
+		errGetImage := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is)
+		require.Error(t, errGetImage, "output imagestream should not be created")
+
+		bc := &buildv1.BuildConfig{}
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc)
+		require.Error(t, errGetBC, "build config should not be created without an output imagestream")
+
+		dc := &appsv1.DeploymentConfig{}
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+		require.NoError(t, errGetDC, "deployment config is not created")
+		require.Equal(t, "quay.io/example/myapp:1.0", dc.Spec.Template.Spec.Containers[0].Image, "deployment config should use the external image")
+		require.Equal(t, 1, len(dc.Spec.Triggers), "deployment config should only be triggered on config change")
+		require.Equal(t, appsv1.DeploymentTriggerOnConfigChange, dc.Spec.Triggers[0].Type)
+
+		svc := &corev1.Service{}
+		errGetSvc := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc)
+		require.NoError(t, errGetSvc, "service is not created")
+		require.Equal(t, int32(8080), svc.Spec.Ports[0].Port, "default service port should be 8080")
+	})
+
+	t.Run("with ReconcileComponent CR skipping the output imagestream without an image", func(t *testing.T) {
+		//given
+		cpExternal := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				SkipOutputImageStream: true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(cpExternal)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile should not be retried when no image is provided")
+		require.False(t, res.Requeue, "reconcile should not requeue a terminal error")
+
+		instance := &devconsoleapi.Component{}
+		errGet := cl.Get(context.Background(), req.NamespacedName, instance)
+		require.NoError(t, errGet, "component should still exist")
+		condition := getCondition(instance, ConditionReconcileFailed)
+		require.NotNil(t, condition, "reconcile failure should be recorded")
+		require.Equal(t, "TerminalError", condition.Reason, "missing image is a terminal error")
+
+		dc := &appsv1.DeploymentConfig{}
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+		require.Error(t, errGetDC, "deployment config should not be created")
+	})
+
+	t.Run("with conventionally named source secret detection", func(t *testing.T) {
+		//given
+		gsWithoutSecret := &devconsoleapi.GitSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-git-source",
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.GitSourceSpec{
+				URL: "https://somegit.con/myrepo",
+				Ref: "master",
+			},
+		}
+		cpDetect := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:          "nodejs",
+				GitSourceRef:       "my-git-source",
+				Port:               8080,
+				DetectSourceSecret: true,
+			},
+		}
+		conventionalSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name + "-git-secret",
+				Namespace: Namespace,
+			},
+			Type: corev1.SecretTypeBasicAuth,
+		}
+		objs := []runtime.Object{
+			gsWithoutSecret,
+			cpDetect,
+			conventionalSecret,
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(objs...)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+
+		bc := &buildv1.BuildConfig{}
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc)
+		require.NoError(t, errGetBC, "build config is not created")
+		require.NotNil(t, bc.Spec.Source.SourceSecret, "conventional source secret should be attached")
+		require.Equal(t, Name+"-git-secret", bc.Spec.Source.SourceSecret.Name)
+
+		// without detection the conventional secret is ignored
+		cpDetect.Spec.DetectSourceSecret = false
+		cl = fake.NewFakeClient(gsWithoutSecret, cpDetect, conventionalSecret)
+		r = &ReconcileComponent{client: cl, scheme: s}
+
+		_, err = r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		bc = &buildv1.BuildConfig{}
+		errGetBC = cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc)
+		require.NoError(t, errGetBC, "build config is not created")
+		require.Nil(t, bc.Spec.Source.SourceSecret, "source secret should only be detected when requested")
+	})
+
+	t.Run("with ReconcileComponent CR whose builder imagestream fails to import", func(t *testing.T) {
+		//given
+		cpImport := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		isNodejs := &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nodejs",
+				Namespace: "openshift",
+			},
+			Status: imagev1.ImageStreamStatus{
+				Tags: []imagev1.NamedTagEventList{{
+					Tag: "latest",
+					Conditions: []imagev1.TagEventCondition{{
+						Type:    imagev1.ImportSuccess,
+						Status:  corev1.ConditionFalse,
+						Reason:  "InternalError",
+						Message: "registry unreachable",
+					}},
+				}},
+			},
+		}
+		objs := []runtime.Object{
+			gs,
+			cpImport,
+			isNodejs,
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(objs...)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+
+		instance := &devconsoleapi.Component{}
+		errGet := cl.Get(context.TODO(), req.NamespacedName, instance)
+		require.NoError(t, errGet, "component is not created")
+		condition := getCondition(instance, ConditionImageImportFailed)
+		require.NotNil(t, condition, "component should report the ImageImportFailed condition")
+		require.Equal(t, corev1.ConditionTrue, condition.Status)
+		require.Equal(t, "ImportFailed", condition.Reason)
+		require.Contains(t, condition.Message, "registry unreachable")
+
+		// once the import succeeds, the condition is cleared
+		isNodejs.Status.Tags[0].Conditions = nil
+		require.NoError(t, cl.Update(context.TODO(), isNodejs))
+
+		_, err = r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		instance = &devconsoleapi.Component{}
+		errGet = cl.Get(context.TODO(), req.NamespacedName, instance)
+		require.NoError(t, errGet, "component is not created")
+		require.Equal(t, corev1.ConditionFalse, getCondition(instance, ConditionImageImportFailed).Status)
+	})
+
+	t.Run("with ReconcileComponent CR with autoscaling does not reconcile replica drift", func(t *testing.T) {
+		//given
+		cpAutoscaled := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				MinReplicas:  2,
+				MaxReplicas:  5,
+			},
+		}
+		objs := []runtime.Object{
+			gs,
+			cpAutoscaled,
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(objs...)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+
+		dc := &appsv1.DeploymentConfig{}
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+		require.NoError(t, errGetDC, "deployment config is not created")
+		require.Equal(t, int32(2), dc.Spec.Replicas, "initial replicas should match the autoscaler minimum")
+		hpa := &autoscalingv1.HorizontalPodAutoscaler{}
+		errGetHPA := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, hpa)
+		require.NoError(t, errGetHPA, "horizontal pod autoscaler is not created")
+		require.Equal(t, "DeploymentConfig", hpa.Spec.ScaleTargetRef.Kind, "autoscaler should scale the deployment config")
+		require.Equal(t, Name, hpa.Spec.ScaleTargetRef.Name, "autoscaler should scale the deployment config")
+		require.Equal(t, int32(2), *hpa.Spec.MinReplicas, "autoscaler minimum should be set")
+		require.Equal(t, int32(5), hpa.Spec.MaxReplicas, "autoscaler maximum should be set")
+
+		// the autoscaler scales the deployment config up
+		dc.Spec.Replicas = 4
+		require.NoError(t, cl.Update(context.TODO(), dc))
+
+		_, err = r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		dc = &appsv1.DeploymentConfig{}
+		errGetDC = cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+		require.NoError(t, errGetDC, "deployment config is not created")
+		require.Equal(t, int32(4), dc.Spec.Replicas, "replicas set by the autoscaler should be left untouched")
+	})
+
+	t.Run("with ReconcileComponent CR disabling the route with a feature gate annotation", func(t *testing.T) {
+		//given
+		cpGated := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+				Annotations: map[string]string{
+					"devconsole.io/feature-route": "false",
+				},
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         Port,
+				Exposed:      true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpGated)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+
+		svc := &corev1.Service{}
+		errGetSvc := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc)
+		require.NoError(t, errGetSvc, "service is not created")
+
+		rte := &routev1.Route{}
+		errGetRte := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, rte)
+		require.Error(t, errGetRte, "route should not be created when its feature gate is disabled")
+
+		// when the gate is turned back on
+		instance := &devconsoleapi.Component{}
+		err = cl.Get(context.Background(), req.NamespacedName, instance)
+		require.NoError(t, err, "component is not found")
+		instance.Annotations["devconsole.io/feature-route"] = "true"
+		err = cl.Update(context.Background(), instance)
+		require.NoError(t, err, "component is not updated")
+		_, err = r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		rte = &routev1.Route{}
+		errGetRte = cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, rte)
+		require.NoError(t, errGetRte, "route should be created when its feature gate is enabled")
+	})
+
+	t.Run("with ReconcileComponent CR whose deployment config is unavailable", func(t *testing.T) {
+		//given
+		gracePeriod := int32(30)
+		cpDegraded := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:                  "nodejs",
+				GitSourceRef:               "my-git-source",
+				Port:                       8080,
+				DegradedGracePeriodSeconds: &gracePeriod,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDegraded)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		setUnavailableSince := func(since time.Time) {
+			dc := &appsv1.DeploymentConfig{}
+			errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+			require.NoError(t, errGetDC, "deployment config is not created")
+			dc.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:               appsv1.DeploymentAvailable,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(since),
+				Message:            "Deployment config does not have minimum availability.",
+			}}
+			require.NoError(t, cl.Update(context.TODO(), dc))
+		}
+
+		//when
+		setUnavailableSince(time.Now().Add(-10 * time.Second))
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.True(t, res.RequeueAfter > 0 && res.RequeueAfter <= 20*time.Second, "component should be checked again once the grace period is over")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		condition := getCondition(instance, ConditionDegraded)
+		require.NotNil(t, condition, "degraded condition should be reported")
+		require.Equal(t, corev1.ConditionFalse, condition.Status, "component should not be degraded within the grace period")
+
+		//when
+		setUnavailableSince(time.Now().Add(-60 * time.Second))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		instance = &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		condition = getCondition(instance, ConditionDegraded)
+		require.NotNil(t, condition, "degraded condition should be reported")
+		require.Equal(t, corev1.ConditionTrue, condition.Status, "component should be degraded after the grace period")
+		require.Equal(t, "Unavailable", condition.Reason)
+	})
+
+	t.Run("with ReconcileComponent CR and an audit sink", func(t *testing.T) {
+		//given
+		cpAudited := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpAudited)
+		sink := &stubAuditSink{}
+
+		// Create a ReconcileComponent object with the scheme, fake client and audit sink.
+		r := &ReconcileComponent{client: cl, scheme: s, auditSink: sink}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		var created []string
+		for _, record := range sink.records {
+			require.Equal(t, Name, record.Component, "audit record should reference the component")
+			if record.Action == audit.ActionCreate {
+				created = append(created, record.Kind+"/"+record.Name)
+			}
+		}
+		require.Equal(t, []string{
+			"ImageStream/" + Name,
+			"ImageStream/nodejs",
+			"BuildConfig/" + Name,
+			"DeploymentConfig/" + Name,
+			"Service/" + Name,
+		}, created, "every created resource should be audited")
+
+		//when
+		sink.records = nil
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Empty(t, sink.records, "neither existing resources nor status writes should be audited")
+	})
+
+	t.Run("with ReconcileComponent CR orphaning a deleted resource", func(t *testing.T) {
+		//given
+		cpOrphan := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Exposed:      true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpOrphan)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		// the user deletes the route and the service, and only orphans the route
+		rte := &routev1.Route{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, rte))
+		require.NoError(t, cl.Delete(context.Background(), rte))
+		svc := &corev1.Service{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc))
+		require.NoError(t, cl.Delete(context.Background(), svc))
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Annotations = map[string]string{"devconsole.io/orphan": "route"}
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		errGetRte := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &routev1.Route{})
+		require.Error(t, errGetRte, "orphaned route should not be recreated")
+		errGetSvc := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &corev1.Service{})
+		require.NoError(t, errGetSvc, "service which is not orphaned should be recreated")
+	})
+
+	t.Run("with ReconcileComponent CR with additional output tags", func(t *testing.T) {
+		//given
+		cpTagged := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				OutputTags:   []string{"built", "promoted"},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpTagged)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is))
+		require.Equal(t, []string{"built", "promoted"}, tagNames(is), "output tags should be created")
+		require.Equal(t, Name+":latest", is.Spec.Tags[0].From.Name, "output tags should point to the latest built image")
+
+		//when
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.OutputTags = append(instance.Spec.OutputTags, "qa")
+		require.NoError(t, cl.Update(context.Background(), instance))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is = &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is))
+		require.Equal(t, []string{"built", "promoted", "qa"}, tagNames(is), "missing output tags should be added to the existing imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR without buildtype in a namespace with a default build type", func(t *testing.T) {
+		//given
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: Namespace,
+				Annotations: map[string]string{
+					"devconsole.io/default-build-type": "nodejs",
+				},
+			},
+		}
+		cpDefaulted := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(ns, gs, cpDefaulted)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "nodejs", instance.Spec.BuildType, "namespace default build type should be applied")
+
+		builderIS := &imagev1.ImageStream{}
+		errGetBuilder := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "nodejs"}, builderIS)
+		require.NoError(t, errGetBuilder, "builder imagestream of the namespace default build type is not created")
+	})
+
+	t.Run("with ReconcileComponent CR deploying a pre-built image while preparing the build", func(t *testing.T) {
+		//given
+		cpPrebuilt := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Image:        "quay.io/example/app:1.0",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpPrebuilt)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+
+		dc := &appsv1.DeploymentConfig{}
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+		require.NoError(t, errGetDC, "deployment config is not created")
+		require.Equal(t, "quay.io/example/app:1.0", dc.Spec.Template.Spec.Containers[0].Image, "pre-built image should be deployed")
+		require.Len(t, dc.Spec.Triggers, 1, "deployment should not wait for a build")
+		require.Equal(t, appsv1.DeploymentTriggerOnConfigChange, dc.Spec.Triggers[0].Type)
+
+		bc := &buildv1.BuildConfig{}
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc)
+		require.NoError(t, errGetBC, "build config should be created for future builds")
+		require.Empty(t, bc.Spec.Triggers, "build config should not be triggered")
+	})
+
+	t.Run("with ReconcileComponent CR owning the generated resources", func(t *testing.T) {
+		//given
+		cpOwner := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+				UID:       "component-uid",
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Exposed:      true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpOwner)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		children := map[string]runtime.Object{
+			"output imagestream": &imagev1.ImageStream{},
+			"build config":       &buildv1.BuildConfig{},
+			"deployment config":  &appsv1.DeploymentConfig{},
+			"service":            &corev1.Service{},
+			"route":              &routev1.Route{},
+		}
+		for kind, child := range children {
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, child), "%s is not created", kind)
+			owners := child.(metav1.Object).GetOwnerReferences()
+			require.Len(t, owners, 1, "%s should be owned by the component", kind)
+			require.Equal(t, Name, owners[0].Name, "%s should be owned by the component", kind)
+			require.Equal(t, types.UID("component-uid"), owners[0].UID, "%s should be owned by the component", kind)
+			require.True(t, *owners[0].Controller, "component should be the controller of the %s", kind)
+		}
+	})
+
+	t.Run("with ReconcileComponent CR reading its git ref from a ConfigMap", func(t *testing.T) {
+		//given
+		release := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "release",
+				Namespace: Namespace,
+			},
+			Data: map[string]string{
+				"ref": "v1.0",
+			},
+		}
+		cpRefFrom := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				GitRefFrom: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "release"},
+					Key:                  "ref",
+				},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, release, cpRefFrom)
+		clBuild := fakebuild.NewSimpleClientset()
+		clBuild.PrependReactor("create", "buildconfigs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, &buildv1.Build{}, nil
+		})
+
+		// Create a ReconcileComponent object with the scheme and fake clients.
+		r := &ReconcileComponent{client: cl, scheme: s, buildClient: clBuild.BuildV1()}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Equal(t, "v1.0", bc.Spec.Source.Git.Ref, "git ref should be read from the ConfigMap")
+
+		//when
+		release.Data["ref"] = "v1.1"
+		require.NoError(t, cl.Update(context.Background(), release))
+		requests := componentsReferencingGitRef(cl)(handler.MapObject{Meta: release, Object: release})
+
+		//then
+		require.Equal(t, []reconcile.Request{req}, requests, "a change of the ConfigMap should reconcile the component")
+
+		//when
+		_, err = r.Reconcile(requests[0])
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		bc = &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Equal(t, "v1.1", bc.Spec.Source.Git.Ref, "build config should build the new git ref")
+		actions := clBuild.Actions()
+		require.Len(t, actions, 1, "a new build should be started")
+		require.Equal(t, "instantiate", actions[0].GetSubresource(), "a new build should be started")
+	})
+
+	t.Run("with ReconcileComponent CR pausing its builds", func(t *testing.T) {
+		//given
+		cpPaused := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpPaused)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		dcBefore := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dcBefore))
+
+		//when
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.PauseBuilds = true
+		require.NoError(t, cl.Update(context.Background(), instance))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Empty(t, bc.Spec.Triggers, "build triggers should be removed while builds are paused")
+		dcAfter := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dcAfter))
+		require.Equal(t, dcBefore, dcAfter, "deployment config should be left untouched")
+
+		//when
+		instance = &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.PauseBuilds = false
+		require.NoError(t, cl.Update(context.Background(), instance))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		bc = &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Len(t, bc.Spec.Triggers, 2, "build triggers should be restored once builds are resumed")
+	})
+
+	t.Run("with ReconcileComponent CR requiring an approval", func(t *testing.T) {
+		//given
+		cpGated := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:        "nodejs",
+				GitSourceRef:     "my-git-source",
+				Port:             8080,
+				ApprovalRequired: true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpGated, newTestResolvedOutputImageStream())
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, approvalRequeueDelay, res.RequeueAfter, "reconcile should requeue until approved")
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created before approval")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		condition := getCondition(instance, ConditionApproved)
+		require.NotNil(t, condition, "approved condition should be reported")
+		require.Equal(t, corev1.ConditionFalse, condition.Status, "component should be waiting for approval")
+		require.Equal(t, "AwaitingApproval", getCondition(instance, ConditionReady).Reason, "component should not be ready before approval")
+		require.Nil(t, getCondition(instance, ConditionDeploymentCreated), "deployment config should not be reported as created before approval")
+
+		//when
+		instance.Annotations = map[string]string{"devconsole.io/approved": "true"}
+		require.NoError(t, cl.Update(context.Background(), instance))
+		res, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Zero(t, res.RequeueAfter, "approved component should not be requeued")
+		errGetDC = cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.NoError(t, errGetDC, "deployment config should be created once approved")
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionDeploymentCreated).Status, "deployment config should be reported as created")
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionReady).Status, "approved component should be ready")
+	})
+
+	t.Run("with ReconcileComponent CR not exposed", func(t *testing.T) {
+		//given
+		cpInternal := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpInternal)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		errGetSvc := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &corev1.Service{})
+		require.NoError(t, errGetSvc, "service is not created")
+		errGetRte := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &routev1.Route{})
+		require.Error(t, errGetRte, "route should only be created when the component is exposed")
+	})
+
+	t.Run("with ReconcileComponent CR waiting for its output image to be built", func(t *testing.T) {
+		//given
+		cpWaiting := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpWaiting)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, imageResolutionRequeueDelay, res.RequeueAfter, "reconcile should requeue until the output image is built")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		condition := getCondition(instance, ConditionImageResolved)
+		require.NotNil(t, condition, "image resolution should be reported")
+		require.Equal(t, corev1.ConditionFalse, condition.Status, "output image should not be resolved before the build")
+
+		//when
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is))
+		is.Status = newTestResolvedOutputImageStream().Status
+		require.NoError(t, cl.Update(context.Background(), is))
+		res, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Zero(t, res.RequeueAfter, "reconcile should not requeue once the output image is built")
+		instance = &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionImageResolved).Status, "output image should be resolved")
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionReady).Status, "component should be ready once its image is built")
+	})
+
+	t.Run("with ReconcileComponent CR whose resources miss a managed label", func(t *testing.T) {
+		//given
+		cpLabeled := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+				Labels:    map[string]string{"app.kubernetes.io/version": "2.0"},
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		existingSvc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+				Labels:    map[string]string{"app": Name, "team": "frontend"},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpLabeled, existingSvc)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		svc := &corev1.Service{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc))
+		require.Equal(t, "2.0", svc.Labels["app.kubernetes.io/version"], "managed label should be added to the existing service")
+		require.Equal(t, "frontend", svc.Labels["team"], "unmanaged label should be preserved")
+	})
+
+	t.Run("with ReconcileComponent CR with an unsupported buildtype", func(t *testing.T) {
+		//given
+		cpUnsupported := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs14",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpUnsupported)
+		recorder := record.NewFakeRecorder(10)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, recorder: recorder}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "unsupported buildtype should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "unsupported buildtype should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.NotNil(t, ready, "readiness should be reported")
+		require.Equal(t, corev1.ConditionFalse, ready.Status, "component with an unsupported buildtype should not be ready")
+		require.Equal(t, "UnsupportedBuildType", ready.Reason, "unsupported buildtype should be reported")
+		require.Equal(t, `unsupported build type "nodejs14", supported: [dotnet golang java nodejs python ruby] or an ImageStream of the openshift namespace`, ready.Message)
+		require.Equal(t, phaseFailed, instance.Status.Phase, "component with an unsupported buildtype should be failed")
+		require.Contains(t, recordedEvents(recorder), "Warning UnsupportedBuildType "+ready.Message, "unsupported buildtype should be recorded as an event")
+		errGetBuilderImage := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "nodejs14"}, &imagev1.ImageStream{})
+		require.Error(t, errGetBuilderImage, "builder imagestream should not be created")
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR with java buildtype", func(t *testing.T) {
+		//given
+		cpJava := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "java",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpJava)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		isBuilder := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "java"}, isBuilder), "builder imagestream is not created")
+		require.Equal(t, 1, len(isBuilder.Spec.Tags), "imagestream builder should have a tag specified")
+		require.Equal(t, "DockerImage", isBuilder.Spec.Tags[0].From.Kind, "imagestream builder should be taken from docker when not found in cluster")
+		require.Equal(t, "fabric8/s2i-java:latest", isBuilder.Spec.Tags[0].From.Name, "imagestream builder should be taken from fabric8/s2i-java:latest")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, "java:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "build config should build from the java builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with its output imagestream in another namespace", func(t *testing.T) {
+		//given
+		cpShared := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:                  "nodejs",
+				GitSourceRef:               "my-git-source",
+				Port:                       8080,
+				OutputImageStreamNamespace: "registry",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpShared)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: "registry", Name: Name}, is), "output imagestream is not created in the registry namespace")
+		require.Empty(t, is.OwnerReferences, "output imagestream in another namespace cannot be owned by the component")
+		errGetLocalIS := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &imagev1.ImageStream{})
+		require.Error(t, errGetLocalIS, "output imagestream should not be created in the component namespace")
+
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, "registry", bc.Spec.Output.To.Namespace, "build should push to the registry namespace")
+
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc), "deployment config is not created")
+		require.Equal(t, appsv1.DeploymentTriggerOnImageChange, dc.Spec.Triggers[1].Type, "deployment config should be triggered on image change")
+		require.Equal(t, "registry", dc.Spec.Triggers[1].ImageChangeParams.From.Namespace, "deployment config should be triggered by the registry imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with a port out of range", func(t *testing.T) {
+		//given
+		cpInvalidPort := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         70000,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpInvalidPort)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "invalid port should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "invalid port should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "InvalidPort", ready.Reason, "invalid port should be reported")
+		require.Equal(t, "port 70000 is out of range [1-65535]", ready.Message)
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created with an invalid port")
+		errGetIS := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &imagev1.ImageStream{})
+		require.Error(t, errGetIS, "output imagestream should not be created with an invalid port")
+	})
+
+	t.Run("with ReconcileComponent CR with an unknown buildtype and a builder image", func(t *testing.T) {
+		//given
+		cpOverride := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "rust",
+				BuilderImage: "quay.io/example/rust-s2i:1.0",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpOverride)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		isBuilder := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name + "-builder"}, isBuilder), "builder imagestream is not created")
+		require.Equal(t, "quay.io/example/rust-s2i:1.0", isBuilder.Spec.Tags[0].From.Name, "builder imagestream should import the builder image")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, Name+"-builder:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "build config should build from the builder image")
+	})
+
+	t.Run("with ReconcileComponent CR with an invalid builder image", func(t *testing.T) {
+		//given
+		cpInvalid := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				BuilderImage: "quay.io/example/rust s2i",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpInvalid)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "invalid builder image should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "invalid builder image should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "InvalidBuilderImage", getCondition(instance, ConditionReady).Reason, "invalid builder image should be reported")
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR setting an environment variable twice", func(t *testing.T) {
+		//given
+		cpDuplicateEnv := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Env: []corev1.EnvVar{
+					{Name: "NODE_ENV", Value: "production"},
+					{Name: "NODE_ENV", Value: "development"},
+				},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDuplicateEnv)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "duplicate environment variables should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "duplicate environment variables should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "DuplicateEnv", ready.Reason, "duplicate environment variables should be reported")
+		require.Equal(t, "environment variable NODE_ENV is set more than once", ready.Message)
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR whose deployment config completes rollouts", func(t *testing.T) {
+		//given
+		cpDeployed := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDeployed)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		completeRollout := func(version int64) {
+			dc := &appsv1.DeploymentConfig{}
+			errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc)
+			require.NoError(t, errGetDC, "deployment config is not created")
+			dc.Status.LatestVersion = version
+			dc.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionTrue,
+				Reason: string(appsv1.NewReplicationControllerAvailableReason),
+			}}
+			require.NoError(t, cl.Update(context.TODO(), dc))
+		}
+		deployCount := func() int64 {
+			instance := &devconsoleapi.Component{}
+			require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+			return instance.Status.DeployCount
+		}
+		require.Zero(t, deployCount(), "no rollout completed yet")
+
+		//when
+		completeRollout(1)
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, int64(1), deployCount(), "completed rollout should be counted")
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, int64(1), deployCount(), "completed rollout should only be counted once")
+
+		//when
+		completeRollout(2)
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, int64(2), deployCount(), "next completed rollout should be counted")
+	})
+
+	t.Run("with ReconcileComponent CR with an invalid resource limit", func(t *testing.T) {
+		//given
+		cpInvalidLimit := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:      "nodejs",
+				GitSourceRef:   "my-git-source",
+				Port:           8080,
+				ResourceLimits: map[string]string{"memory": "lots"},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpInvalidLimit)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "invalid resource limit should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "invalid resource limit should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "InvalidResources", ready.Reason, "invalid resource limit should be reported")
+		require.Equal(t, `invalid resource limit: memory "lots" is not a valid quantity`, ready.Message)
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR checking its output registry", func(t *testing.T) {
+		//given
+		cpChecked := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        Name,
+				Namespace:   Namespace,
+				Annotations: map[string]string{"devconsole.io/feature-registry-check": "true"},
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		outputIS := newTestOutputImageStream()
+		outputIS.Status.DockerImageRepository = "image-registry.openshift-image-registry.svc:5000/" + Namespace + "/" + Name
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpChecked, outputIS)
+		checker := &stubRegistryChecker{err: e.New("connection refused")}
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, registryChecker: checker}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, registryRequeueDelay, res.RequeueAfter, "reconcile should requeue while the registry is unreachable")
+		require.Equal(t, []string{"image-registry.openshift-image-registry.svc:5000"}, checker.hosts, "registry of the output imagestream should be checked")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		condition := getCondition(instance, ConditionRegistryReachable)
+		require.NotNil(t, condition, "registry reachability should be reported")
+		require.Equal(t, corev1.ConditionFalse, condition.Status, "registry should be reported as unreachable")
+		require.Contains(t, condition.Message, "connection refused")
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created while the registry is unreachable")
+
+		//when
+		checker.err = nil
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionRegistryReachable).Status, "registry should be reported as reachable")
+		errGetBC = cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.NoError(t, errGetBC, "build config should be created once the registry is reachable")
+	})
+
+	t.Run("with ReconcileComponent CR only detecting drift", func(t *testing.T) {
+		//given
+		cpObserved := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:          "nodejs",
+				GitSourceRef:       "my-git-source",
+				Port:               8080,
+				OutputTags:         []string{"promoted"},
+				DriftDetectionOnly: true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpObserved)
+		sink := &stubAuditSink{}
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, auditSink: sink}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+
+		// the user edits the generated resources
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		bc.Spec.Triggers = nil
+		bc.Labels = map[string]string{"team": "frontend"}
+		require.NoError(t, cl.Update(context.Background(), bc))
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is))
+		is.Spec.Tags = nil
+		require.NoError(t, cl.Update(context.Background(), is))
+		sink.records = nil
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, []string{
+			"ImageStream " + Name + ": tags",
+			"BuildConfig " + Name + ": labels",
+			"BuildConfig " + Name + ": triggers",
+		}, instance.Status.Drift, "drift should be reported")
+		for _, record := range sink.records {
+			require.NotEqual(t, audit.ActionUpdate, record.Action, "%s %s should not be updated", record.Kind, record.Name)
+		}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Empty(t, bc.Spec.Triggers, "drifted triggers should be left untouched")
+		require.Equal(t, map[string]string{"team": "frontend"}, bc.Labels, "drifted labels should be left untouched")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is))
+		require.Empty(t, is.Spec.Tags, "drifted tags should be left untouched")
+	})
+
+	t.Run("with ReconcileComponent CR with negative replicas", func(t *testing.T) {
+		//given
+		cpNegative := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Replicas:     -1,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpNegative)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "negative replicas should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "negative replicas should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "InvalidReplicas", ready.Reason, "negative replicas should be reported")
+		require.Equal(t, "replicas -1 must not be negative", ready.Message)
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR changing an environment variable", func(t *testing.T) {
+		//given
+		cpEnv := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Env:          []corev1.EnvVar{{Name: "NODE_ENV", Value: "development"}},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpEnv)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		hash := dc.Spec.Template.Annotations[configHashAnnotation]
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.Env[0].Value = "production"
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.NotEqual(t, hash, dc.Spec.Template.Annotations[configHashAnnotation], "config hash of the pod template should change")
+		require.Equal(t, []corev1.EnvVar{{Name: "NODE_ENV", Value: "production"}}, dc.Spec.Template.Spec.Containers[0].Env, "container should get the new environment")
+	})
+
+	t.Run("with ReconcileComponent CR with an unsupported build strategy", func(t *testing.T) {
+		//given
+		cpUnsupported := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:     "nodejs",
+				GitSourceRef:  "my-git-source",
+				Port:          8080,
+				BuildStrategy: "custom",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpUnsupported)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "unsupported build strategy should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "unsupported build strategy should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "UnsupportedBuildStrategy", ready.Reason, "unsupported build strategy should be reported")
+		require.Equal(t, `unsupported build strategy "custom", supported: [source docker auto]`, ready.Message)
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR building with an end of life builder image", func(t *testing.T) {
+		//given
+		cpNodejs := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpNodejs)
+		recorder := record.NewFakeRecorder(10)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, recorder: recorder}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Contains(t, recordedEvents(recorder), "Warning DeprecatedBuilderImage the default builder image nodeshift/centos7-s2i-nodejs:10.x of build type nodejs reached its end of life, set a builderImage to upgrade", "end of life builder image should be recorded as an event")
+	})
+
+	t.Run("with ReconcileComponent CR overriding an end of life builder image", func(t *testing.T) {
+		//given
+		cpNodejs := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				BuilderImage: "registry.access.redhat.com/ubi8/nodejs-14",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpNodejs)
+		recorder := record.NewFakeRecorder(10)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, recorder: recorder}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		for _, event := range recordedEvents(recorder) {
+			require.NotContains(t, event, "DeprecatedBuilderImage", "overridden builder image should not be recorded as deprecated")
+		}
+	})
+
+	t.Run("with ReconcileComponent CR recording the creation of its resources", func(t *testing.T) {
+		//given
+		cpEvents := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpEvents)
+		recorder := record.NewFakeRecorder(20)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, recorder: recorder}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		events := recordedEvents(recorder)
+		require.Contains(t, events, "Normal Created Created ImageStream "+Name, "output imagestream creation should be recorded as an event")
+		require.Contains(t, events, "Normal Created Created ImageStream nodejs", "builder imagestream creation should be recorded as an event")
+		require.Contains(t, events, "Normal Created Created BuildConfig "+Name, "build config creation should be recorded as an event")
+		require.Contains(t, events, "Normal Created Created DeploymentConfig "+Name, "deployment config creation should be recorded as an event")
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		for _, event := range recordedEvents(recorder) {
+			require.NotContains(t, event, "Normal Created", "existing resources should not be recorded as created")
+		}
+	})
+
+	t.Run("with ReconcileComponent CR with a selector conflicting with its labels", func(t *testing.T) {
+		//given
+		cpSelector := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Selector:     map[string]string{"app": "api"},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpSelector)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "conflicting selector should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "conflicting selector should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "InvalidSelector", ready.Reason, "conflicting selector should be reported")
+		require.Equal(t, "invalid selector app=api: conflicts with the label app="+Name+" of the component", ready.Message)
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR whose git source moves to another repository", func(t *testing.T) {
+		//given
+		cpMoved := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		gsMoved := gs.DeepCopy()
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gsMoved, cpMoved)
+		clBuild := fakebuild.NewSimpleClientset()
+		clBuild.PrependReactor("create", "buildconfigs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, &buildv1.Build{}, nil
+		})
+
+		// Create a ReconcileComponent object with the scheme and fake clients.
+		r := &ReconcileComponent{client: cl, scheme: s, buildClient: clBuild.BuildV1()}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		gsMoved.Spec.URL = "https://somegit.con/monorepo"
+		require.NoError(t, cl.Update(context.Background(), gsMoved))
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.ContextDir = "services/api"
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Equal(t, "https://somegit.con/monorepo", bc.Spec.Source.Git.URI, "build config should build the new repository")
+		require.Equal(t, "services/api", bc.Spec.Source.ContextDir, "build config should build the new context dir")
+		actions := clBuild.Actions()
+		require.Len(t, actions, 1, "a new build should be started")
+		require.Equal(t, "instantiate", actions[0].GetSubresource(), "a new build should be started")
+	})
+
+	t.Run("with ReconcileComponent CR without the finalizer", func(t *testing.T) {
+		//given
+		cpNew := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpNew)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, []string{componentFinalizer}, instance.Finalizers, "finalizer should be added to the component")
+	})
+
+	t.Run("with ReconcileComponent CR being deleted", func(t *testing.T) {
+		//given
+		cpDeleted := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        Name,
+				Namespace:   Namespace,
+				Annotations: map[string]string{orphanAnnotation: "route"},
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		route := &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: Namespace}}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDeleted, route)
+		sink := &stubAuditSink{}
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, auditSink: sink}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		now := metav1.Now()
+		instance.DeletionTimestamp = &now
+		require.NoError(t, cl.Update(context.Background(), instance))
+		sink.records = nil
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		key := types.NamespacedName{Namespace: Namespace, Name: Name}
+		require.True(t, errors.IsNotFound(cl.Get(context.Background(), key, &appsv1.DeploymentConfig{})), "deployment config should be deleted")
+		require.True(t, errors.IsNotFound(cl.Get(context.Background(), key, &corev1.Service{})), "service should be deleted")
+		require.True(t, errors.IsNotFound(cl.Get(context.Background(), key, &buildv1.BuildConfig{})), "build config should be deleted")
+		require.True(t, errors.IsNotFound(cl.Get(context.Background(), key, &imagev1.ImageStream{})), "output imagestream should be deleted")
+		require.NoError(t, cl.Get(context.Background(), key, &routev1.Route{}), "orphaned route should be kept")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "nodejs"}, &imagev1.ImageStream{}), "shared builder imagestream should be kept")
+		instance = &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Empty(t, instance.Finalizers, "finalizer should be removed from the component")
+		var deleted []string
+		for _, record := range sink.records {
+			if record.Action == audit.ActionDelete {
+				deleted = append(deleted, record.Kind)
+			}
+		}
+		require.Equal(t, []string{"Service", "DeploymentConfig", "BuildConfig", "ImageStream"}, deleted, "deletions should be audited")
+	})
+
+	t.Run("with ReconcileComponent CR ready for longer than its TTL", func(t *testing.T) {
+		//given
+		ttl := int32(60)
+		cpEphemeral := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       Name,
+				Namespace:  Namespace,
+				Finalizers: []string{componentFinalizer},
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:             "nodejs",
+				GitSourceRef:          "my-git-source",
+				Port:                  8080,
+				TTLSecondsAfterDeploy: &ttl,
+			},
+			Status: devconsoleapi.ComponentStatus{
+				Conditions: []devconsoleapi.ComponentCondition{{
+					Type:               ConditionReady,
+					Status:             corev1.ConditionTrue,
+					Reason:             "Reconciled",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				}},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpEphemeral)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, reconcile.Result{}, res, "expired component should not be requeued")
+		errGet := cl.Get(context.Background(), req.NamespacedName, &devconsoleapi.Component{})
+		require.True(t, errors.IsNotFound(errGet), "expired component should be deleted")
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created for an expired component")
+	})
+
+	t.Run("with ReconcileComponent CR ready for less than its TTL", func(t *testing.T) {
+		//given
+		ttl := int32(3600)
+		cpEphemeral := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       Name,
+				Namespace:  Namespace,
+				Finalizers: []string{componentFinalizer},
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:             "nodejs",
+				GitSourceRef:          "my-git-source",
+				Port:                  8080,
+				TTLSecondsAfterDeploy: &ttl,
+			},
+			Status: devconsoleapi.ComponentStatus{
+				Conditions: []devconsoleapi.ComponentCondition{{
+					Type:               ConditionReady,
+					Status:             corev1.ConditionTrue,
+					Reason:             "Reconciled",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+				}},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpEphemeral)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, &devconsoleapi.Component{}), "component should be kept until its TTL is over")
+	})
+
+	t.Run("with ReconcileComponent CR enabling webhooks", func(t *testing.T) {
+		//given
+		cpWebhooks := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:      "nodejs",
+				GitSourceRef:   "my-git-source",
+				Port:           8080,
+				EnableWebhooks: true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpWebhooks)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, apiServerURL: "https://api.example.com:6443"}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		secret := &corev1.Secret{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name + "-webhook"}, secret), "webhook secret should be created")
+		require.NotEmpty(t, secret.Data[webhookSecretKey], "webhook secret should hold a key")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Contains(t, bc.Spec.Triggers, buildv1.BuildTriggerPolicy{
+			Type:          buildv1.GitHubWebHookBuildTriggerType,
+			GitHubWebHook: &buildv1.WebHookTrigger{SecretReference: &buildv1.SecretLocalReference{Name: Name + "-webhook"}},
+		}, "github webhook should use the generated secret")
+		require.Contains(t, bc.Spec.Triggers, buildv1.BuildTriggerPolicy{
+			Type:           buildv1.GenericWebHookBuildTriggerType,
+			GenericWebHook: &buildv1.WebHookTrigger{SecretReference: &buildv1.SecretLocalReference{Name: Name + "-webhook"}},
+		}, "generic webhook should use the generated secret")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Contains(t, instance.Status.WebhookURLs, "https://api.example.com:6443/apis/build.openshift.io/v1/namespaces/"+Namespace+"/buildconfigs/"+Name+"/webhooks/<secret>/github", "github webhook URL should be reported")
+		require.Contains(t, instance.Status.WebhookURLs, "https://api.example.com:6443/apis/build.openshift.io/v1/namespaces/"+Namespace+"/buildconfigs/"+Name+"/webhooks/<secret>/generic", "generic webhook URL should be reported")
+	})
+
+	t.Run("with ReconcileComponent CR without webhooks", func(t *testing.T) {
+		//given
+		cpNoWebhooks := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpNoWebhooks)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, apiServerURL: "https://api.example.com:6443"}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		errGetSecret := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name + "-webhook"}, &corev1.Secret{})
+		require.True(t, errors.IsNotFound(errGetSecret), "webhook secret should not be created")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Equal(t, []buildv1.BuildTriggerType{buildv1.ConfigChangeBuildTriggerType, buildv1.ImageChangeBuildTriggerType}, buildTriggerTypes(bc.Spec.Triggers), "builds should not be triggered by webhooks")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Empty(t, instance.Status.WebhookURLs, "no webhook URL should be reported")
+	})
+
+	t.Run("with ReconcileComponent CR whose build config cannot be updated in place", func(t *testing.T) {
+		//given
+		cpImmutable := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpImmutable)
+		sink := &stubAuditSink{}
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, auditSink: sink}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.PauseBuilds = true
+		require.NoError(t, cl.Update(context.Background(), instance))
+		r.client = immutableBuildConfigClient{cl}
+		sink.records = nil
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config should be recreated")
+		require.Empty(t, bc.Spec.Triggers, "recreated build config should get the desired spec")
+		var actions []string
+		for _, record := range sink.records {
+			if record.Kind == "BuildConfig" {
+				actions = append(actions, record.Action)
+			}
+		}
+		require.Equal(t, []string{audit.ActionDelete, audit.ActionCreate}, actions, "build config should be deleted and created again")
+	})
+
+	t.Run("with ReconcileComponent CR whose openshift builder imagestream is not imported yet", func(t *testing.T) {
+		//given
+		cpPending := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		isNodejs := &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nodejs",
+				Namespace: "openshift",
+			},
+			Status: imagev1.ImageStreamStatus{
+				Tags: []imagev1.NamedTagEventList{},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpPending, isNodejs)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, reconcile.Result{RequeueAfter: builderImportRequeueDelay}, res, "reconcile should be requeued until the builder image is imported")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "WaitingForBuilderImage", getCondition(instance, ConditionReady).Reason, "component should wait for the builder image")
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR as a dry run renders its resources without creating them", func(t *testing.T) {
+		//given
+		cpDryRun := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				DryRun:       true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDryRun)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		isList := &imagev1.ImageStreamList{}
+		require.NoError(t, cl.List(context.Background(), &client.ListOptions{}, isList))
+		require.Empty(t, isList.Items, "no imagestream should be created")
+		bcList := &buildv1.BuildConfigList{}
+		require.NoError(t, cl.List(context.Background(), &client.ListOptions{}, bcList))
+		require.Empty(t, bcList.Items, "no build config should be created")
+		dcList := &appsv1.DeploymentConfigList{}
+		require.NoError(t, cl.List(context.Background(), &client.ListOptions{}, dcList))
+		require.Empty(t, dcList.Items, "no deployment config should be created")
+		svcList := &corev1.ServiceList{}
+		require.NoError(t, cl.List(context.Background(), &client.ListOptions{}, svcList))
+		require.Empty(t, svcList.Items, "no service should be created")
+
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Empty(t, instance.Finalizers, "a dry run has nothing to clean up")
+		require.Len(t, instance.Status.RenderedManifests, 4)
+		require.Contains(t, instance.Status.RenderedManifests[0], "kind: ImageStream\n")
+		require.Contains(t, instance.Status.RenderedManifests[1], "kind: BuildConfig\n")
+		require.Contains(t, instance.Status.RenderedManifests[2], "kind: DeploymentConfig\n")
+		require.Contains(t, instance.Status.RenderedManifests[3], "kind: Service\n")
+		require.Contains(t, instance.Status.RenderedManifests[3], "port: 8080\n")
+	})
+
+	t.Run("with ReconcileComponent CR with an ImageStream update policy", func(t *testing.T) {
+		for _, policy := range []string{"", "Merge", "Replace"} {
+			//given
+			cpPolicy := &devconsoleapi.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      Name,
+					Namespace: Namespace,
+				},
+				Spec: devconsoleapi.ComponentSpec{
+					BuildType:               "nodejs",
+					GitSourceRef:            "my-git-source",
+					Port:                    8080,
+					OutputTags:              []string{"promoted"},
+					ImageStreamUpdatePolicy: policy,
+				},
+			}
+			existingIS := &imagev1.ImageStream{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      Name,
+					Namespace: Namespace,
+				},
+				Spec: imagev1.ImageStreamSpec{
+					Tags: []imagev1.TagReference{{Name: "pinned", From: &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.io/team/app:1.0"}}},
+				},
+			}
+			// Create a fake client to mock API calls.
+			cl := fake.NewFakeClient(gs, cpPolicy, existingIS)
+
+			// Create a ReconcileComponent object with the scheme and fake client.
+			r := &ReconcileComponent{client: cl, scheme: s}
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      Name,
+					Namespace: Namespace,
+				},
+			}
+
+			//when
+			_, err := r.Reconcile(req)
+
+			//then
+			require.NoError(t, err, "reconcile is failing with policy %q", policy)
+			is := &imagev1.ImageStream{}
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, is))
+			if policy == "Replace" {
+				require.Equal(t, []string{"promoted"}, tagNames(is), "tags should be overwritten with Replace")
+			} else {
+				require.Equal(t, []string{"pinned", "promoted"}, tagNames(is), "extra tags should be preserved with policy %q", policy)
+			}
+		}
+	})
+
+	t.Run("with ReconcileComponent CR with an unsupported ImageStream update policy", func(t *testing.T) {
+		//given
+		cpUnsupported := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:               "nodejs",
+				GitSourceRef:            "my-git-source",
+				ImageStreamUpdatePolicy: "Patch",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpUnsupported)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "a terminal error should not be retried")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		condition := getCondition(instance, ConditionReconcileFailed)
+		require.NotNil(t, condition)
+		require.Equal(t, "UnsupportedImageStreamUpdatePolicy", condition.Reason)
+		errGetIS := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &imagev1.ImageStream{})
+		require.Error(t, errGetIS, "output imagestream should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR with a completed build records the built commit", func(t *testing.T) {
+		//given
+		cpBuilt := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		newBuild := func(name string, phase buildv1.BuildPhase, completion time.Time, commit string) *buildv1.Build {
+			build := &buildv1.Build{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: Namespace,
+					Labels:    map[string]string{"openshift.io/build-config.name": Name},
+				},
+				Spec: buildv1.BuildSpec{CommonSpec: buildv1.CommonSpec{
+					Revision: &buildv1.SourceRevision{Git: &buildv1.GitSourceRevision{Commit: commit}},
+				}},
+				Status: buildv1.BuildStatus{Phase: phase},
+			}
+			if phase == buildv1.BuildPhaseComplete {
+				build.Status.CompletionTimestamp = &metav1.Time{Time: completion}
+			}
+			return build
+		}
+		now := time.Now()
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpBuilt,
+			newBuild(Name+"-1", buildv1.BuildPhaseComplete, now.Add(-time.Hour), "1111111"),
+			newBuild(Name+"-2", buildv1.BuildPhaseComplete, now.Add(-time.Minute), "2222222"),
+			newBuild(Name+"-3", buildv1.BuildPhaseRunning, now, "3333333"))
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "2222222", instance.Status.BuiltCommit, "the commit of the latest completed build should be recorded")
+	})
+
+	t.Run("with ReconcileComponent CR with several ports", func(t *testing.T) {
+		//given
+		cpPorts := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Ports:        []int32{8080, 9090},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpPorts)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Equal(t, []corev1.ContainerPort{
+			{ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+			{ContainerPort: 9090, Protocol: corev1.ProtocolTCP},
+		}, dc.Spec.Template.Spec.Containers[0].Ports, "all the ports should be exposed by the container")
+		svc := &corev1.Service{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc))
+		require.Equal(t, int32(8080), svc.Spec.Ports[0].Port, "the service should expose the first port")
+	})
+
+	t.Run("with ReconcileComponent CR with a duplicated port", func(t *testing.T) {
+		//given
+		cpDuplicatedPort := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Ports:        []int32{8080, 8080},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDuplicatedPort)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "invalid port should not be retried")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "InvalidPort", ready.Reason, "duplicated port should be reported")
+		require.Equal(t, "port 8080 is exposed more than once", ready.Message)
+	})
+
+	t.Run("with ReconcileComponent CR summarizes its state in the status message", func(t *testing.T) {
+		//given
+		cpSummarized := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Exposed:      true,
+			},
+		}
+		existingDC := &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec:   appsv1.DeploymentConfigSpec{Replicas: 3},
+			Status: appsv1.DeploymentConfigStatus{Replicas: 3, ReadyReplicas: 3},
+		}
+		existingRoute := &routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: routev1.RouteSpec{
+				Host: "app.example.com",
+				Port: &routev1.RoutePort{TargetPort: intstr.FromInt(8080)},
+				TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpSummarized, newTestResolvedOutputImageStream(), existingDC, existingRoute)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "Build Complete, 3/3 pods ready, exposed at https://app.example.com", instance.Status.Message)
+	})
+
+	t.Run("with ReconcileComponent CR with python buildtype", func(t *testing.T) {
+		//given
+		cpPython := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "python",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpPython)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "python"}, is), "builder imagestream is not created")
+		require.Equal(t, "DockerImage", is.Spec.Tags[0].From.Kind)
+		require.Equal(t, "centos/python-36-centos7:latest", is.Spec.Tags[0].From.Name, "builder imagestream should import the python S2I image")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, "python:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "build should use the python builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with golang buildtype", func(t *testing.T) {
+		//given
+		cpGolang := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "golang",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpGolang)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "golang"}, is), "builder imagestream is not created")
+		require.Equal(t, "DockerImage", is.Spec.Tags[0].From.Kind)
+		require.Equal(t, "centos/go-toolset-7-centos7:latest", is.Spec.Tags[0].From.Name, "builder imagestream should import the golang S2I image")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, "golang:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "build should use the golang builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with dotnet buildtype", func(t *testing.T) {
+		//given
+		cpDotnet := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "dotnet",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDotnet)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "dotnet"}, is), "builder imagestream is not created")
+		require.Equal(t, "DockerImage", is.Spec.Tags[0].From.Kind)
+		require.Equal(t, "registry.access.redhat.com/dotnet/dotnet-22-rhel7:2.2", is.Spec.Tags[0].From.Name, "builder imagestream should import the .NET Core S2I image")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, "dotnet:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "build should use the dotnet builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with ruby buildtype", func(t *testing.T) {
+		//given
+		cpRuby := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "ruby",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpRuby)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "ruby"}, is), "builder imagestream is not created")
+		require.Equal(t, "DockerImage", is.Spec.Tags[0].From.Kind)
+		require.Equal(t, "centos/ruby-25-centos7:latest", is.Spec.Tags[0].From.Name, "builder imagestream should import the ruby S2I image")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, "ruby:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "build should use the ruby builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with an involved object records its build events on it", func(t *testing.T) {
+		//given
+		cpInvolved := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				InvolvedObject: &corev1.ObjectReference{
+					APIVersion: "apps.example.com/v1",
+					Kind:       "Application",
+					Name:       "shop",
+				},
+			},
+		}
+		completedBuild := &buildv1.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name + "-1",
+				Namespace: Namespace,
+				Labels:    map[string]string{"openshift.io/build-config.name": Name},
+			},
+			Spec: buildv1.BuildSpec{CommonSpec: buildv1.CommonSpec{
+				Revision: &buildv1.SourceRevision{Git: &buildv1.GitSourceRevision{Commit: "1111111"}},
+			}},
+			Status: buildv1.BuildStatus{Phase: buildv1.BuildPhaseComplete, CompletionTimestamp: &metav1.Time{Time: time.Now()}},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpInvolved, completedBuild)
+		recorder := &involvedObjectRecorder{FakeRecorder: record.NewFakeRecorder(20)}
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, recorder: recorder}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		built := "Normal Built Build " + Name + "-1 completed at commit 1111111"
+		var builtEvents int
+		for _, event := range recordedEvents(recorder.FakeRecorder) {
+			if event == built {
+				builtEvents++
+			}
+		}
+		require.Equal(t, 2, builtEvents, "the build event should be recorded on the component and on the involved object")
+		require.Contains(t, recorder.objects, &corev1.ObjectReference{
+			APIVersion: "apps.example.com/v1",
+			Kind:       "Application",
+			Namespace:  Namespace,
+			Name:       "shop",
+		}, "the involved object should default to the namespace of the component")
+	})
+
+	t.Run("with two ReconcileComponent CRs of the same buildtype sharing their builder imagestream", func(t *testing.T) {
+		//given
+		newNodejsComponent := func(name string, uid types.UID) *devconsoleapi.Component {
+			return &devconsoleapi.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: Namespace,
+					UID:       uid,
+				},
+				Spec: devconsoleapi.ComponentSpec{
+					BuildType:    "nodejs",
+					GitSourceRef: "my-git-source",
+					Port:         8080,
+				},
+			}
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, newNodejsComponent("frontend", "frontend-uid"), newNodejsComponent("backend", "backend-uid"))
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		frontend := reconcile.Request{NamespacedName: types.NamespacedName{Name: "frontend", Namespace: Namespace}}
+		backend := reconcile.Request{NamespacedName: types.NamespacedName{Name: "backend", Namespace: Namespace}}
+		_, err := r.Reconcile(frontend)
+		require.NoError(t, err, "reconcile is failing")
+		_, err = r.Reconcile(backend)
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), frontend.NamespacedName, instance))
+		now := metav1.Now()
+		instance.DeletionTimestamp = &now
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		_, err = r.Reconcile(frontend)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.True(t, errors.IsNotFound(cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "frontend"}, &buildv1.BuildConfig{})), "build config of the deleted component should be deleted")
+		builder := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "nodejs"}, builder), "shared builder imagestream should survive the deletion of one of its components")
+		require.Empty(t, builder.OwnerReferences, "shared builder imagestream should not be garbage collected with any component")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "backend"}, bc))
+		require.Equal(t, "nodejs:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "remaining component should still build from the shared builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with a shared builder imagestream owned by a component", func(t *testing.T) {
+		//given
+		cpLegacy := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		controller := true
+		ownedBuilder := &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nodejs",
+				Namespace: Namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: devconsoleapi.SchemeGroupVersion.String(),
+					Kind:       "Component",
+					Name:       "former",
+					UID:        "former-uid",
+					Controller: &controller,
+				}},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpLegacy, ownedBuilder)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		builder := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "nodejs"}, builder))
+		require.Empty(t, builder.OwnerReferences, "component owners should be removed from the shared builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with a dedicated builder imagestream", func(t *testing.T) {
+		//given
+		cpDedicated := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+				UID:       "component-uid",
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:                   "nodejs",
+				GitSourceRef:                "my-git-source",
+				Port:                        8080,
+				DedicatedBuilderImageStream: true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDedicated)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		builder := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name + "-builder"}, builder), "dedicated builder imagestream is not created")
+		require.Equal(t, "nodeshift/centos7-s2i-nodejs:10.x", builder.Spec.Tags[0].From.Name, "dedicated builder imagestream should import the image of the build type")
+		require.Len(t, builder.OwnerReferences, 1, "dedicated builder imagestream should be owned by the component")
+		require.Equal(t, types.UID("component-uid"), builder.OwnerReferences[0].UID)
+		require.Equal(t, Name, builder.Labels["app"], "dedicated builder imagestream should carry the labels of the component")
+		require.True(t, errors.IsNotFound(cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "nodejs"}, &imagev1.ImageStream{})), "shared builder imagestream should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR referencing a GitSource with an invalid URL", func(t *testing.T) {
+		//given
+		gsInvalid := newTestGitSource()
+		gsInvalid.Spec.URL = "somegit.con/myrepo"
+		cpInvalidURL := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gsInvalid, cpInvalidURL)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "invalid URL should not be retried")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "InvalidGitURL", ready.Reason, "invalid URL should be reported")
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created with an invalid URL")
+	})
+
+	t.Run("with two ReconcileComponent CRs of the same name pushing to the same namespace", func(t *testing.T) {
+		//given
+		newSharedComponent := func(namespace string) *devconsoleapi.Component {
+			return &devconsoleapi.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      Name,
+					Namespace: namespace,
+				},
+				Spec: devconsoleapi.ComponentSpec{
+					BuildType:                  "nodejs",
+					GitSourceRef:               "my-git-source",
+					Port:                       8080,
+					OutputImageStreamNamespace: "registry",
+					OutputTags:                 []string{"promoted"},
+				},
+			}
+		}
+		gsOther := newTestGitSource()
+		gsOther.Namespace = "other"
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, gsOther, newSharedComponent(Namespace), newSharedComponent("other"))
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		first := reconcile.Request{NamespacedName: types.NamespacedName{Name: Name, Namespace: Namespace}}
+		second := reconcile.Request{NamespacedName: types.NamespacedName{Name: Name, Namespace: "other"}}
+		_, err := r.Reconcile(first)
+		require.NoError(t, err, "reconcile is failing")
+
+		//when
+		_, err = r.Reconcile(second)
+
+		//then
+		require.NoError(t, err, "a collision should not be retried")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), second.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "NameCollision", ready.Reason, "collision should be reported")
+		require.Equal(t, "ImageStream registry/"+Name+" already belongs to Component "+Namespace+"/"+Name+", rename the component to avoid the collision", ready.Message)
+		require.True(t, errors.IsNotFound(cl.Get(context.Background(), types.NamespacedName{Namespace: "other", Name: Name}, &buildv1.BuildConfig{})), "build config should not be created for the colliding component")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: "registry", Name: Name}, is))
+		require.Equal(t, Namespace+"/"+Name, is.Annotations[ownerAnnotation], "output imagestream should still belong to the first component")
+
+		//when
+		_, err = r.Reconcile(first)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		instance = &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), first.NamespacedName, instance))
+		require.NotEqual(t, "NameCollision", getCondition(instance, ConditionReady).Reason, "the first component should keep its output imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR named after a DeploymentConfig owned by another object", func(t *testing.T) {
+		//given
+		cpColliding := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+				UID:       "component-uid",
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		controller := true
+		ownedDC := &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps.example.com/v1",
+					Kind:       "Application",
+					Name:       "shop",
+					UID:        "shop-uid",
+					Controller: &controller,
+				}},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpColliding, ownedDC)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "a collision should not be retried")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "NameCollision", ready.Reason, "collision should be reported")
+		require.Equal(t, "DeploymentConfig "+Namespace+"/"+Name+" already belongs to Application shop, rename the component to avoid the collision", ready.Message)
+	})
+
+	t.Run("with ReconcileComponent CR whose generation is already reconciled", func(t *testing.T) {
+		//given
+		cpObserved := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       Name,
+				Namespace:  Namespace,
+				Generation: 1,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := &countingClient{Client: fake.NewFakeClient(gs, cpObserved, newTestResolvedOutputImageStream())}
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		require.NotZero(t, cl.creates, "first reconcile should create the resources")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, int64(1), instance.Status.ObservedGeneration, "reconciled generation should be recorded")
+		cl.creates, cl.updates = 0, 0
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Zero(t, cl.creates, "second reconcile should not create anything")
+		require.Zero(t, cl.updates, "second reconcile should not update anything")
+
+		//when
+		require.NoError(t, cl.Delete(context.Background(), &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: Namespace}}))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &corev1.Service{}), "missing service should be recreated")
+	})
+
+	t.Run("with ReconcileComponent CR with the auto build strategy", func(t *testing.T) {
+		for _, found := range []bool{true, false} {
+			//given
+			cpAuto := &devconsoleapi.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      Name,
+					Namespace: Namespace,
+				},
+				Spec: devconsoleapi.ComponentSpec{
+					BuildType:     "nodejs",
+					GitSourceRef:  "my-git-source",
+					Port:          8080,
+					ContextDir:    "services/api",
+					BuildStrategy: "auto",
+				},
+			}
+			// Create a fake client to mock API calls.
+			cl := fake.NewFakeClient(gs, cpAuto)
+			detector := &stubDockerfileDetector{found: found}
+
+			// Create a ReconcileComponent object with the scheme and fake client.
+			r := &ReconcileComponent{client: cl, scheme: s, dockerfileDetector: detector}
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      Name,
+					Namespace: Namespace,
+				},
+			}
+
+			//when
+			_, err := r.Reconcile(req)
+
+			//then
+			require.NoError(t, err, "reconcile is failing")
+			require.Equal(t, []string{"services/api"}, detector.contextDirs, "the Dockerfile should be looked up in the context directory")
+			bc := &buildv1.BuildConfig{}
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+			instance := &devconsoleapi.Component{}
+			require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+			if found {
+				require.NotNil(t, bc.Spec.Strategy.DockerStrategy, "a repository with a Dockerfile should be built with the docker strategy")
+				require.Nil(t, bc.Spec.Strategy.SourceStrategy)
+				require.Equal(t, "docker", instance.Status.BuildStrategy, "detected strategy should be reported")
+			} else {
+				require.NotNil(t, bc.Spec.Strategy.SourceStrategy, "a repository without Dockerfile should be built with S2I")
+				require.Nil(t, bc.Spec.Strategy.DockerStrategy)
+				require.Equal(t, "source", instance.Status.BuildStrategy, "detected strategy should be reported")
+			}
+		}
+	})
+
+	t.Run("with ReconcileComponent CR whose git ref changes during a build", func(t *testing.T) {
+		//given
+		cpBuilding := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		gsBuilding := gs.DeepCopy()
+		running := &buildv1.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: Name + "-1", Namespace: Namespace, Labels: map[string]string{buildConfigLabel: Name}},
+			Status:     buildv1.BuildStatus{Phase: buildv1.BuildPhaseRunning},
+		}
+		complete := &buildv1.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: Name + "-0", Namespace: Namespace, Labels: map[string]string{buildConfigLabel: Name}},
+			Status:     buildv1.BuildStatus{Phase: buildv1.BuildPhaseComplete},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gsBuilding, cpBuilding, running, complete)
+		clBuild := fakebuild.NewSimpleClientset()
+		clBuild.PrependReactor("create", "buildconfigs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, &buildv1.Build{}, nil
+		})
+
+		// Create a ReconcileComponent object with the scheme and fake clients.
+		r := &ReconcileComponent{client: cl, scheme: s, buildClient: clBuild.BuildV1()}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		gsBuilding.Spec.Ref = "v2"
+		require.NoError(t, cl.Update(context.Background(), gsBuilding))
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: running.Name}, running))
+		require.True(t, running.Status.Cancelled, "in-flight build should be cancelled")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: complete.Name}, complete))
+		require.False(t, complete.Status.Cancelled, "completed build should be left alone")
+		actions := clBuild.Actions()
+		require.Len(t, actions, 1, "a new build should be started")
+		require.Equal(t, "instantiate", actions[0].GetSubresource(), "a new build should be started")
+	})
+
+	t.Run("with ReconcileComponent CR waiting for the endpoints of a dependency", func(t *testing.T) {
+		//given
+		cpDependent := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:        "nodejs",
+				GitSourceRef:     "my-git-source",
+				Port:             8080,
+				WaitForEndpoints: []string{"postgresql"},
+			},
+		}
+		endpoints := &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "postgresql", Namespace: Namespace},
+			Subsets: []corev1.EndpointSubset{{
+				NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+			}},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpDependent, endpoints, newTestResolvedOutputImageStream())
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, dependencyRequeueDelay, res.RequeueAfter, "reconcile should requeue until the dependency is ready")
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created before the dependency is ready")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		condition := getCondition(instance, ConditionDependenciesReady)
+		require.NotNil(t, condition, "dependencies condition should be reported")
+		require.Equal(t, corev1.ConditionFalse, condition.Status, "component should be waiting for its dependency")
+		require.Equal(t, "waiting for a ready endpoint of Service postgresql", condition.Message)
+		require.Equal(t, "WaitingForDependencies", getCondition(instance, ConditionReady).Reason, "component should not be ready before its dependency")
+
+		//when
+		endpoints.Subsets = []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+		}}
+		require.NoError(t, cl.Update(context.Background(), endpoints))
+		res, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Zero(t, res.RequeueAfter, "component with a ready dependency should not be requeued")
+		errGetDC = cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.NoError(t, errGetDC, "deployment config should be created once the dependency is ready")
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionDependenciesReady).Status, "dependencies should be reported as ready")
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionReady).Status, "component should be ready")
+	})
+
+	t.Run("with ReconcileComponent CR whose output image is built", func(t *testing.T) {
+		//given
+		cpBuilt := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpBuilt, newTestResolvedOutputImageStream())
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "sha256:4f6a1b2c", instance.Status.ImageDigest, "digest of the built image should be recorded")
+	})
+
+	t.Run("with ReconcileComponent CR changed outside of its maintenance window", func(t *testing.T) {
+		//given
+		cpWindowed := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       Name,
+				Namespace:  Namespace,
+				Generation: 1,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:         "nodejs",
+				GitSourceRef:      "my-git-source",
+				Port:              8080,
+				MaintenanceWindow: "22:00-06:00",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpWindowed, newTestResolvedOutputImageStream())
+		now := time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, now: func() time.Time { return now }}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{}), "first deployment should not wait for the maintenance window")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.Env = []corev1.EnvVar{{Name: "NODE_ENV", Value: "production"}}
+		instance.Generation = 2
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.Equal(t, 10*time.Hour, res.RequeueAfter, "reconcile should requeue once the maintenance window opens")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Empty(t, dc.Spec.Template.Spec.Containers[0].Env, "change should be deferred outside of the maintenance window")
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		deferred := getCondition(instance, ConditionChangesDeferred)
+		require.NotNil(t, deferred, "deferred changes should be reported")
+		require.Equal(t, corev1.ConditionTrue, deferred.Status, "changes should be deferred")
+		require.Equal(t, "changes are deferred until the maintenance window 22:00-06:00 UTC opens", deferred.Message)
+
+		//when
+		now = time.Date(2019, 4, 1, 23, 0, 0, 0, time.UTC)
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Equal(t, []corev1.EnvVar{{Name: "NODE_ENV", Value: "production"}}, dc.Spec.Template.Spec.Containers[0].Env, "change should be applied inside the maintenance window")
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, corev1.ConditionFalse, getCondition(instance, ConditionChangesDeferred).Status, "changes should no longer be deferred")
+		require.Equal(t, int64(2), instance.Status.ObservedGeneration, "changed generation should be reconciled")
+	})
+
+	t.Run("with ReconcileComponent CR whose resources are edited", func(t *testing.T) {
+		//given
+		cpEdited := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:                   "nodejs",
+				GitSourceRef:                "my-git-source",
+				Port:                        8080,
+				BuilderImage:                "quay.io/example/nodejs:12",
+				DedicatedBuilderImageStream: true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpEdited)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		eventHandler := ownedByComponent()
+		require.NoError(t, eventHandler.(inject.Scheme).InjectScheme(s))
+		children := []runtime.Object{&appsv1.DeploymentConfig{}, &buildv1.BuildConfig{}, &imagev1.ImageStream{}}
+		names := []string{Name, Name, Name + "-builder"}
+		for i, child := range children {
+			require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: names[i]}, child))
+			edited := child.DeepCopyObject()
+			accessor, err := meta.Accessor(edited)
+			require.NoError(t, err)
+			accessor.SetLabels(map[string]string{"edited": "true"})
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+			//when
+			eventHandler.Update(event.UpdateEvent{MetaOld: accessor, ObjectOld: child, MetaNew: accessor, ObjectNew: edited}, queue)
+
+			//then
+			require.Equal(t, 1, queue.Len(), "edit of %T %s should reconcile its component", child, names[i])
+			item, _ := queue.Get()
+			require.Equal(t, req, item, "edit of %T %s should reconcile its component", child, names[i])
+		}
+	})
+
+	t.Run("with ReconcileComponent CR pinning its deployed image", func(t *testing.T) {
+		//given
+		cpPinned := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:        "nodejs",
+				GitSourceRef:     "my-git-source",
+				Port:             8080,
+				PinDeployedImage: true,
+			},
+		}
+		outputIS := newTestResolvedOutputImageStream()
+		outputIS.Status.DockerImageRepository = "172.30.1.1:5000/" + Namespace + "/" + Name
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpPinned, outputIS)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		pinned := "172.30.1.1:5000/" + Namespace + "/" + Name + "@sha256:4f6a1b2c"
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Equal(t, pinned, dc.Spec.Template.Spec.Containers[0].Image, "deployment config should deploy the pinned image")
+		require.False(t, hasImageChangeTrigger(dc), "deployment config should not roll out new builds")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, pinned, instance.Status.PinnedImage, "pinned image should be recorded")
+
+		//when
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, outputIS))
+		outputIS.Status.Tags[0].Items = append([]imagev1.TagEvent{{Image: "sha256:9e8d7c6b"}}, outputIS.Status.Tags[0].Items...)
+		require.NoError(t, cl.Update(context.Background(), outputIS))
+		instance.Spec.Env = []corev1.EnvVar{{Name: "NODE_ENV", Value: "production"}}
+		instance.Generation++
+		require.NoError(t, cl.Update(context.Background(), instance))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Equal(t, pinned, dc.Spec.Template.Spec.Containers[0].Image, "new build should not be deployed")
+		require.Equal(t, []corev1.EnvVar{{Name: "NODE_ENV", Value: "production"}}, dc.Spec.Template.Spec.Containers[0].Env, "changed config should be rolled out")
+
+		//when
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.PinDeployedImage = false
+		instance.Generation++
+		require.NoError(t, cl.Update(context.Background(), instance))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.True(t, hasImageChangeTrigger(dc), "unpinned deployment config should roll out new builds again")
+		require.Equal(t, Name+":latest", dc.Spec.Template.Spec.Containers[0].Image, "unpinned deployment config should track the latest tag")
+	})
+
+	t.Run("with ReconcileComponent CR with an output tag", func(t *testing.T) {
+		//given
+		cpTagged := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				OutputTag:    "v1.2.3",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpTagged)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Equal(t, Name+":v1.2.3", bc.Spec.Output.To.Name, "build should push to the output tag")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Equal(t, Name+":v1.2.3", imageChangeTag(dc), "deployment config should roll out the images of the output tag")
+		require.Equal(t, Name+":v1.2.3", dc.Spec.Template.Spec.Containers[0].Image, "deployment config should deploy the output tag")
+
+		//when
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.OutputTag = "v1.2.4"
+		instance.Generation++
+		require.NoError(t, cl.Update(context.Background(), instance))
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Equal(t, Name+":v1.2.4", bc.Spec.Output.To.Name, "build should push to the new output tag")
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Equal(t, Name+":v1.2.4", imageChangeTag(dc), "deployment config should roll out the images of the new output tag")
+	})
+
+	t.Run("with ReconcileComponent CR whose builder is found in a shared namespace", func(t *testing.T) {
+		//given
+		cpShared := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		sharedBuilder := &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: "builders"},
+			Status: imagev1.ImageStreamStatus{
+				Tags: []imagev1.NamedTagEventList{{Tag: "latest", Items: []imagev1.TagEvent{{Image: "sha256:1a2b3c4d"}}}},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpShared, sharedBuilder)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s, sharedBuilderNamespaces: []string{"tools", "builders"}}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		errGetBuilder := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "nodejs"}, &imagev1.ImageStream{})
+		require.True(t, errors.IsNotFound(errGetBuilder), "local builder imagestream should not be created")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		require.Equal(t, corev1.ObjectReference{Kind: "ImageStreamTag", Namespace: "builders", Name: "nodejs:latest"}, bc.Spec.Strategy.SourceStrategy.From, "build should use the shared builder imagestream")
+	})
+
+	t.Run("with ReconcileComponent CR with volumes", func(t *testing.T) {
+		//given
+		cpVolumes := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Volumes: []devconsoleapi.VolumeSpec{
+					{Name: "data", MountPath: "/var/lib/data", Size: "5Gi"},
+					{Name: "shared", MountPath: "/var/lib/shared", ClaimName: "existing-claim"},
+				},
+			},
+		}
+		existing := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing-claim", Namespace: Namespace},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpVolumes, existing)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		pvc := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name + "-data"}, pvc))
+		storage := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		require.Equal(t, "5Gi", storage.String())
+		require.Empty(t, pvc.OwnerReferences, "claim should outlive the component")
+		existingPVC := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: "existing-claim"}, existingPVC))
+		require.Empty(t, existingPVC.Labels, "existing claim should be left untouched")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		podSpec := dc.Spec.Template.Spec
+		require.Len(t, podSpec.Volumes, 2)
+		require.Equal(t, Name+"-data", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+		require.Equal(t, "existing-claim", podSpec.Volumes[1].PersistentVolumeClaim.ClaimName)
+		require.Equal(t, []corev1.VolumeMount{
+			{Name: "data", MountPath: "/var/lib/data"},
+			{Name: "shared", MountPath: "/var/lib/shared"},
+		}, podSpec.Containers[0].VolumeMounts)
+	})
+
+	t.Run("with ReconcileComponent CR whose output ImageStream already exists", func(t *testing.T) {
+		//given
+		cpExisting := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		existing := &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: Namespace},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpExisting, existing)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile should not fail on the existing output ImageStream")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc))
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		outputIS := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, outputIS))
+		require.Equal(t, componentLabels(cpExisting), outputIS.Labels, "existing output ImageStream should be adopted")
+	})
+
+	t.Run("with ReconcileComponent CR with a build type version", func(t *testing.T) {
+		//given
+		cpVersion := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:        "nodejs",
+				BuildTypeVersion: "12.x",
+				GitSourceRef:     "my-git-source",
+				Port:             8080,
+			},
+		}
+		openshiftNodejs := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: openshiftNamespace}}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpVersion, openshiftNodejs)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		is := &imagev1.ImageStream{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name + "-builder"}, is), "builder imagestream is not created")
+		require.Equal(t, "nodeshift/centos7-s2i-nodejs:12.x", is.Spec.Tags[0].From.Name, "builder imagestream should import the requested version")
+		bc := &buildv1.BuildConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, bc), "build config is not created")
+		require.Equal(t, Namespace, bc.Spec.Strategy.SourceStrategy.From.Namespace, "build should not use the builder of the openshift namespace")
+		require.Equal(t, Name+"-builder:latest", bc.Spec.Strategy.SourceStrategy.From.Name, "build should use the builder imagestream of the version")
+	})
+
+	t.Run("with ReconcileComponent CR with a version of a build type without default image", func(t *testing.T) {
+		//given
+		cpVersion := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:        "php",
+				BuildTypeVersion: "7.1",
+				GitSourceRef:     "my-git-source",
+				Port:             8080,
+			},
+		}
+		openshiftPHP := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "php", Namespace: openshiftNamespace}}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpVersion, openshiftPHP)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "invalid build type version should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "invalid build type version should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.NotNil(t, ready, "readiness should be reported")
+		require.Equal(t, "InvalidBuildTypeVersion", ready.Reason, "invalid build type version should be reported")
+		require.Equal(t, `build type "php" cannot be built with version 7.1, supported: [dotnet golang java nodejs python ruby]`, ready.Message)
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.Error(t, errGetBC, "build config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR whose status is updated concurrently", func(t *testing.T) {
+		//given
+		cpGated := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:        "nodejs",
+				GitSourceRef:     "my-git-source",
+				Port:             8080,
+				ApprovalRequired: true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpGated, newTestResolvedOutputImageStream())
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		r.client = conflictingStatusClient{cl}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "status conflict should not fail the reconcile")
+		require.Equal(t, reconcile.Result{Requeue: true}, res, "status conflict should requeue the request")
+	})
+
+	t.Run("with ReconcileComponent CR logs every line with its request", func(t *testing.T) {
+		//given
+		cpLogged := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpLogged)
+		sink := &recordingLogger{lines: &[]map[string]interface{}{}}
+		defer func(previous logr.Logger) { log = previous }(log)
+		log = sink
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		require.NotEmpty(t, *sink.lines, "reconcile should log")
+		for _, line := range *sink.lines {
+			require.Equal(t, Namespace, line["Request.Namespace"], "line %q should carry the namespace of the request", line["msg"])
+			require.Equal(t, Name, line["Request.Name"], "line %q should carry the name of the request", line["msg"])
+		}
+	})
+
+	t.Run("with ReconcileComponent CR deploying a pre-built image without building it", func(t *testing.T) {
+		//given
+		cpPrebuilt := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType: buildTypeNone,
+				Port:      8080,
+				Image:     "quay.io/example/app:1.0",
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(cpPrebuilt)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		errGetBC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &buildv1.BuildConfig{})
+		require.True(t, errors.IsNotFound(errGetBC), "build config should not be created")
+		errGetBuilder := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: buildTypeNone}, &imagev1.ImageStream{})
+		require.True(t, errors.IsNotFound(errGetBuilder), "builder imagestream should not be created")
+		errGetOutput := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &imagev1.ImageStream{})
+		require.True(t, errors.IsNotFound(errGetOutput), "output imagestream should not be created")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc), "deployment config is not created")
+		require.Equal(t, "quay.io/example/app:1.0", dc.Spec.Template.Spec.Containers[0].Image, "pre-built image should be deployed")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, corev1.ConditionTrue, getCondition(instance, ConditionReady).Status, "component should be ready without a build")
+	})
+
+	t.Run("with ReconcileComponent CR with the none build type and no image", func(t *testing.T) {
+		//given
+		cpPrebuilt := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType: buildTypeNone,
+				Port:      8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(cpPrebuilt)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "missing image should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "missing image should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		require.Equal(t, "MissingImage", getCondition(instance, ConditionReady).Reason, "missing image should be reported")
+		errGetDC := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, &appsv1.DeploymentConfig{})
+		require.Error(t, errGetDC, "deployment config should not be created")
+	})
+
+	t.Run("with ReconcileComponent CR exposing a port out of range", func(t *testing.T) {
+		//given
+		cpOutOfRange := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Ports:        []int32{8080, 70000},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpOutOfRange)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		res, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "invalid port should not be retried")
+		require.Equal(t, reconcile.Result{}, res, "invalid port should not be requeued")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		ready := getCondition(instance, ConditionReady)
+		require.Equal(t, "InvalidPort", ready.Reason, "invalid port should be reported")
+		require.Equal(t, "port 70000 is out of range [1-65535]", ready.Message)
+		for _, child := range []runtime.Object{&appsv1.DeploymentConfig{}, &corev1.Service{}, &buildv1.BuildConfig{}, &imagev1.ImageStream{}} {
+			err := cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, child)
+			require.True(t, errors.IsNotFound(err), "%T should not be created with an invalid port", child)
+		}
+	})
+
+	t.Run("with ReconcileComponent CR whose port, replicas and strategy changed", func(t *testing.T) {
+		//given
+		cpChanged := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Exposed:      true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpChanged)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Spec.Port = 9090
+		instance.Spec.Replicas = 3
+		instance.Spec.DeployStrategy = deployStrategyRolling
+		instance.Spec.ImagePullSecrets = []string{"registry-pull"}
+		instance.Generation++
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		svc := &corev1.Service{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc))
+		require.Len(t, svc.Spec.Ports, 1)
+		require.Equal(t, int32(9090), svc.Spec.Ports[0].Port, "service port should be updated")
+		require.Equal(t, 9090, svc.Spec.Ports[0].TargetPort.IntValue(), "service target port should be updated")
+		route := &routev1.Route{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, route))
+		require.Equal(t, 9090, route.Spec.Port.TargetPort.IntValue(), "route target port should be updated")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		require.Equal(t, int32(9090), dc.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort, "container port should be updated")
+		require.Equal(t, 9090, dc.Spec.Template.Spec.Containers[0].ReadinessProbe.TCPSocket.Port.IntValue(), "probes should target the new port")
+		require.Equal(t, int32(3), dc.Spec.Replicas, "replicas should be updated")
+		require.Equal(t, appsv1.DeploymentStrategyTypeRolling, dc.Spec.Strategy.Type, "strategy should be updated")
+		require.Equal(t, []corev1.LocalObjectReference{{Name: "registry-pull"}}, dc.Spec.Template.Spec.ImagePullSecrets, "image pull secrets should be updated")
+	})
+
+	t.Run("with ReconcileComponent enforcing a limit request ratio", func(t *testing.T) {
+		//given
+		cpRatio := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:        "nodejs",
+				GitSourceRef:     "my-git-source",
+				Port:             8080,
+				ResourceRequests: map[string]string{"memory": "256Mi"},
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpRatio)
+
+		// Create a ReconcileComponent object with the scheme, fake client and limit request ratio.
+		r := &ReconcileComponent{client: cl, scheme: s, limitRequestRatio: 2}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+
+		//when
+		_, err := r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		limits := dc.Spec.Template.Spec.Containers[0].Resources.Limits
+		require.Equal(t, "512Mi", limits.Memory().String(), "memory limit should be derived with the ratio of the reconciler")
+	})
+
+	t.Run("with ReconcileComponent CR whose lifecycle, token mount, selector and anti-affinity changed", func(t *testing.T) {
+		//given
+		cpChanged := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       Name,
+				Namespace:  Namespace,
+				Generation: 1,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpChanged)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		automount := false
+		instance.Spec.PreStop = &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "sleep 10"}}}
+		instance.Spec.AutomountServiceAccountToken = &automount
+		instance.Spec.Selector = map[string]string{"tier": "api"}
+		instance.Spec.Replicas = 2
+		instance.Generation++
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		dc := &appsv1.DeploymentConfig{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, dc))
+		podSpec := dc.Spec.Template.Spec
+		require.NotNil(t, podSpec.Containers[0].Lifecycle, "preStop hook should be added")
+		require.Equal(t, []string{"sh", "-c", "sleep 10"}, podSpec.Containers[0].Lifecycle.PreStop.Exec.Command)
+		require.Equal(t, &automount, podSpec.AutomountServiceAccountToken, "token mount should be updated")
+		require.NotNil(t, podSpec.Affinity, "anti-affinity should be added along with the replicas")
+		require.Equal(t, map[string]string{"tier": "api"}, dc.Spec.Selector, "selector should be updated")
+		require.Equal(t, "api", dc.Spec.Template.Labels["tier"], "pods should carry the new selector")
+		require.Equal(t, Name+":latest", podSpec.Containers[0].Image, "image should be left to the image trigger")
+	})
+
+	t.Run("with ReconcileComponent CR whose Service and Route were edited", func(t *testing.T) {
+		//given
+		cpEdited := &devconsoleapi.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       Name,
+				Namespace:  Namespace,
+				Generation: 1,
+			},
+			Spec: devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Port:         8080,
+				Exposed:      true,
+			},
+		}
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(gs, cpEdited)
+
+		// Create a ReconcileComponent object with the scheme and fake client.
+		r := &ReconcileComponent{client: cl, scheme: s}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      Name,
+				Namespace: Namespace,
+			},
+		}
+		_, err := r.Reconcile(req)
+		require.NoError(t, err, "reconcile is failing")
+		svc := &corev1.Service{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc))
+		desiredSvcSpec := svc.Spec
+		svc.Spec.Selector = map[string]string{"app": "other"}
+		svc.Spec.ClusterIP = "172.30.0.10"
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Name: "debug", Port: 5858, TargetPort: intstr.FromInt(5858)})
+		require.NoError(t, cl.Update(context.Background(), svc))
+		route := &routev1.Route{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, route))
+		route.Spec.Host = "app.example.com"
+		route.Spec.To.Name = "other"
+		route.Spec.Path = "/debug"
+		require.NoError(t, cl.Update(context.Background(), route))
+		instance := &devconsoleapi.Component{}
+		require.NoError(t, cl.Get(context.Background(), req.NamespacedName, instance))
+		instance.Generation++
+		require.NoError(t, cl.Update(context.Background(), instance))
+
+		//when
+		_, err = r.Reconcile(req)
+
+		//then
+		require.NoError(t, err, "reconcile is failing")
+		svc = &corev1.Service{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, svc))
+		require.Equal(t, desiredSvcSpec.Selector, svc.Spec.Selector, "service selector should be restored")
+		require.Equal(t, desiredSvcSpec.Ports, svc.Spec.Ports, "service ports should be restored")
+		require.Equal(t, "172.30.0.10", svc.Spec.ClusterIP, "cluster IP should be kept")
+		route = &routev1.Route{}
+		require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Namespace: Namespace, Name: Name}, route))
+		require.Equal(t, Name, route.Spec.To.Name, "route should target the service again")
+		require.Empty(t, route.Spec.Path, "route path should be restored")
+		require.Equal(t, "app.example.com", route.Spec.Host, "route host should be kept")
+	})
+}
+
+func TestResolveBuilderImageStream(t *testing.T) {
+	s := scheme.Scheme
+	require.NoError(t, imagev1.AddToScheme(s))
+	openshiftNodejs := &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: openshiftNamespace}}
+
+	tests := []struct {
+		name        string
+		buildType   string
+		objs        []runtime.Object
+		expected    corev1.ObjectReference
+		create      bool
+		unsupported bool
+	}{
+		{"builder in openshift namespace", "nodejs", []runtime.Object{openshiftNodejs},
+			corev1.ObjectReference{Kind: "ImageStreamTag", Namespace: openshiftNamespace, Name: "nodejs:latest"}, false, false},
+		{"builder from docker map", "nodejs", nil,
+			corev1.ObjectReference{Kind: "ImageStreamTag", Namespace: Namespace, Name: "nodejs:latest"}, true, false},
+		{"unknown buildtype", "cobol", nil, corev1.ObjectReference{}, false, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//given
+			cp := &devconsoleapi.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: Namespace},
+				Spec:       devconsoleapi.ComponentSpec{BuildType: tc.buildType},
+			}
+			r := &ReconcileComponent{client: fake.NewFakeClient(tc.objs...), scheme: s}
+
+			//when
+			builder, create, err := r.resolveBuilderImageStream(cp)
+
+			//then
+			if tc.unsupported {
+				require.EqualError(t, err, `no builder image found for build type "cobol"`)
+				require.Equal(t, "UnsupportedBuildType", errorReason(err, ""), "unsupported buildtype should be reported")
+				require.Equal(t, phaseFailed, cp.Status.Phase, "component should be failed")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, builder)
+			require.Equal(t, tc.create, create)
+		})
+	}
+
+}
+
+func fakeImageStreamImage(imageName string, ports []string, containerConfig string) *imagev1.ImageStreamImage {
+	exposedPorts := make(map[string]struct{})
+	var s struct{}
+	for _, port := range ports {
+		exposedPorts[port] = s
+	}
+	builderImage := &imagev1.ImageStreamImage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s@sha256:9579a93ee", imageName),
+			Namespace: "openshift",
+		},
+		Image: imagev1.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "sha256:9579a93ee",
+			},
+			DockerImageMetadata: runtime.RawExtension{
+				Object: &dockerapiv10.DockerImage{
+					ContainerConfig: dockerapiv10.DockerConfig{
+						ExposedPorts: exposedPorts,
+					},
+				},
+			},
+			DockerImageReference: fmt.Sprintf("docker.io/centos/%s-36-centos7@sha256:9579a93ee", imageName),
+		},
+	}
+	if containerConfig != "" {
+		(*builderImage).Image.DockerImageMetadata.Raw = []byte(containerConfig)
+	}
+	return builderImage
+}
+
+// stubAuditSink keeps the audit records in memory.
+type stubAuditSink struct {
+	records []audit.Record
+}
+
+func (s *stubAuditSink) Emit(record audit.Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func tagNames(is *imagev1.ImageStream) []string {
+	var names []string
+	for _, tag := range is.Spec.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// stubRegistryChecker fails the registry checks with err, and records the checked hosts.
+type stubRegistryChecker struct {
+	err   error
+	hosts []string
+}
+
+func (s *stubRegistryChecker) Check(ctx context.Context, host string) error {
+	s.hosts = append(s.hosts, host)
+	return s.err
+}
+
+// recordedEvents returns the events recorded so far by the given recorder.
+func recordedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// immutableBuildConfigClient rejects the updates of BuildConfigs as if they changed a field which cannot be updated
+// in place.
+type immutableBuildConfigClient struct {
+	client.Client
+}
+
+func (c immutableBuildConfigClient) Update(ctx context.Context, obj runtime.Object) error {
+	if bc, ok := obj.(*buildv1.BuildConfig); ok {
+		return errors.NewInvalid(schema.GroupKind{Group: "build.openshift.io", Kind: "BuildConfig"}, bc.Name, nil)
+	}
+	return c.Client.Update(ctx, obj)
+}
+
+// involvedObjectRecorder records the objects the events are recorded on, along with the events.
+type involvedObjectRecorder struct {
+	*record.FakeRecorder
+	objects []runtime.Object
+}
+
+func (r *involvedObjectRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.objects = append(r.objects, object)
+	r.FakeRecorder.Event(object, eventtype, reason, message)
+}
+
+// countingClient counts the resources created and updated through it.
+type countingClient struct {
+	client.Client
+	creates, updates int
+}
+
+func (c *countingClient) Create(ctx context.Context, obj runtime.Object) error {
+	c.creates++
+	return c.Client.Create(ctx, obj)
+}
+
+func (c *countingClient) Update(ctx context.Context, obj runtime.Object) error {
+	c.updates++
+	return c.Client.Update(ctx, obj)
+}
+
+// stubDockerfileDetector reports whether the repositories have a Dockerfile, and records the looked up context
+// directories.
+type stubDockerfileDetector struct {
+	found       bool
+	contextDirs []string
+}
+
+func (d *stubDockerfileDetector) HasDockerfile(ctx context.Context, gitSource *devconsoleapi.GitSource, contextDir string) (bool, error) {
+	d.contextDirs = append(d.contextDirs, contextDir)
+	return d.found, nil
+}
+
+// conflictingStatusClient rejects the status updates as if the component had been updated concurrently.
+type conflictingStatusClient struct {
+	client.Client
+}
+
+func (c conflictingStatusClient) Status() client.StatusWriter {
+	return conflictingStatusWriter{}
+}
+
+type conflictingStatusWriter struct{}
+
+func (conflictingStatusWriter) Update(ctx context.Context, obj runtime.Object) error {
+	return errors.NewConflict(schema.GroupResource{Group: "devconsole.openshift.io", Resource: "components"}, Name, e.New("the object has been modified"))
+}
+
+// recordingLogger records the key and values of the lines logged through it, along with their message.
+type recordingLogger struct {
+	values []interface{}
+	lines  *[]map[string]interface{}
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	line := map[string]interface{}{"msg": msg}
+	values := append(append([]interface{}{}, l.values...), keysAndValues...)
+	for i := 0; i+1 < len(values); i += 2 {
+		line[fmt.Sprint(values[i])] = values[i+1]
+	}
+	*l.lines = append(*l.lines, line)
+}
+
+func (l *recordingLogger) Enabled() bool {
+	return true
+}
+
+func (l *recordingLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.Info(msg, keysAndValues...)
+}
+
+func (l *recordingLogger) V(level int) logr.InfoLogger {
+	return l
+}
+
+func (l *recordingLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return &recordingLogger{values: append(append([]interface{}{}, l.values...), keysAndValues...), lines: l.lines}
+}
+
+func (l *recordingLogger) WithName(name string) logr.Logger {
+	return l
 }
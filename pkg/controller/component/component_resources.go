@@ -1,7 +1,10 @@
 package component
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	v1 "github.com/openshift/api/apps/v1"
 	buildv1 "github.com/openshift/api/build/v1"
@@ -12,21 +15,31 @@ import (
 
 	"github.com/redhat-developer/devconsole-operator/pkg/resource"
 
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// newImageStreamFromDocker returns the builder ImageStream importing the builder image of the component. A builder
+// image set on the component takes precedence over the one of its build type, and is imported in an ImageStream of
+// its own as other components of the same build type may not override it. The ImageStream of a build type is shared
+// by the components of the namespace, so it carries none of their labels.
 func newImageStreamFromDocker(cp *devconsoleapi.Component) *imagev1.ImageStream {
-	labels := resource.GetLabelsForCR(cp)
-	annotations := resource.GetAnnotationsForCR(cp)
-
-	if _, ok := buildTypeImages[cp.Spec.BuildType]; !ok {
+	var labels, annotations map[string]string
+	name, image := cp.Spec.BuildType, builderImage(cp)
+	if !sharedBuilderImageStream(cp) {
+		name = cp.Name + "-builder"
+		labels, annotations = componentLabels(cp), componentAnnotations(cp)
+	}
+	if image == "" {
 		return nil
 	}
 	return &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{
-		Name:        cp.Spec.BuildType,
+		Name:        name,
 		Namespace:   cp.Namespace,
 		Labels:      labels,
 		Annotations: annotations,
@@ -34,88 +47,360 @@ func newImageStreamFromDocker(cp *devconsoleapi.Component) *imagev1.ImageStream
 		LookupPolicy: imagev1.ImageLookupPolicy{
 			Local: false,
 		},
-		Tags: []imagev1.TagReference{
+		Tags: scheduleImports(cp, []imagev1.TagReference{
 			{
 				Name: "latest",
 				From: &corev1.ObjectReference{
 					Kind: "DockerImage",
-					Name: buildTypeImages[cp.Spec.BuildType],
+					Name: image,
 				},
 			},
-		},
+		}),
 	}}
 }
 
 func newOutputImageStream(cp *devconsoleapi.Component) *imagev1.ImageStream {
-	labels := resource.GetLabelsForCR(cp)
-	annotations := resource.GetAnnotationsForCR(cp)
+	labels := componentLabels(cp)
+	annotations := componentAnnotations(cp)
+	if outputNamespace(cp) != cp.Namespace {
+		annotations[ownerAnnotation] = cp.Namespace + "/" + cp.Name
+	}
 	return &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{
 		Name:        cp.Name,
-		Namespace:   cp.Namespace,
+		Namespace:   outputNamespace(cp),
 		Labels:      labels,
 		Annotations: annotations,
+	}, Spec: imagev1.ImageStreamSpec{
+		Tags: scheduleImports(cp, newOutputTags(cp)),
 	}}
 }
 
-func newBuildConfig(cp *devconsoleapi.Component, builder *imagev1.ImageStream, gitSource *devconsoleapi.GitSource, secret *corev1.Secret) *buildv1.BuildConfig {
-	labels := resource.GetLabelsForCR(cp)
-	annotations := resource.GetAnnotationsForCR(cp)
+// scheduleImports makes OpenShift periodically re-import the tags referencing an external image when the component
+// asks for it, so that they follow the updates of the image.
+func scheduleImports(cp *devconsoleapi.Component, tags []imagev1.TagReference) []imagev1.TagReference {
+	if !cp.Spec.ScheduledImport {
+		return tags
+	}
+	for i := range tags {
+		if tags[i].From != nil && tags[i].From.Kind == "DockerImage" {
+			tags[i].ImportPolicy.Scheduled = true
+		}
+	}
+	return tags
+}
+
+// newProbe returns a probe of the given HTTP endpoint of the container, or a TCP check of the container port when
+// the component does not set one.
+func newProbe(spec *devconsoleapi.ProbeSpec, containerPort int32, initialDelaySeconds int32) *corev1.Probe {
+	probe := &corev1.Probe{
+		Handler: corev1.Handler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(int(containerPort))},
+		},
+		InitialDelaySeconds: initialDelaySeconds,
+	}
+	if spec != nil {
+		port := containerPort
+		if spec.Port != 0 {
+			port = spec.Port
+		}
+		probe.Handler = corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{Path: spec.Path, Port: intstr.FromInt(int(port))},
+		}
+	}
+	return probe
+}
+
+// outputNamespace returns the namespace of the output ImageStream of the component.
+func outputNamespace(cp *devconsoleapi.Component) string {
+	if cp.Spec.OutputImageStreamNamespace != "" {
+		return cp.Spec.OutputImageStreamNamespace
+	}
+	return cp.Namespace
+}
+
+// outputTag returns the tag of the output ImageStream the component is built to and deployed from.
+func outputTag(cp *devconsoleapi.Component) string {
+	if cp.Spec.OutputTag != "" {
+		return cp.Spec.OutputTag
+	}
+	return "latest"
+}
+
+// newOutputTags returns the additional tags of the output ImageStream. They point to the latest built image until a
+// promotion workflow retags them.
+func newOutputTags(cp *devconsoleapi.Component) []imagev1.TagReference {
+	var tags []imagev1.TagReference
+	for _, tag := range cp.Spec.OutputTags {
+		// the built image is pushed to the output tag, which cannot track itself
+		if tag == outputTag(cp) {
+			continue
+		}
+		tags = append(tags, imagev1.TagReference{
+			Name: tag,
+			From: &corev1.ObjectReference{
+				Kind: "ImageStreamTag",
+				Name: cp.Name + ":" + outputTag(cp),
+			},
+		})
+	}
+	return tags
+}
+
+func newBuildConfig(cp *devconsoleapi.Component, builder corev1.ObjectReference, gitSource *devconsoleapi.GitSource, secret *corev1.Secret) *buildv1.BuildConfig {
+	labels := componentLabels(cp)
+	annotations := componentAnnotations(cp)
 	buildSource := buildv1.BuildSource{
 		Git: &buildv1.GitBuildSource{
 			URI: gitSource.Spec.URL,
-			Ref: gitSource.Spec.Ref,
+			Ref: gitRef(cp, gitSource),
 		},
-		Type: buildv1.BuildSourceGit,
+		ContextDir: cp.Spec.ContextDir,
+		Type:       buildv1.BuildSourceGit,
+	}
+	for _, source := range cp.Spec.ImageSources {
+		var paths []buildv1.ImageSourcePath
+		for _, path := range source.Paths {
+			paths = append(paths, buildv1.ImageSourcePath{
+				SourcePath:     path.SourcePath,
+				DestinationDir: path.DestinationDir,
+			})
+		}
+		buildSource.Images = append(buildSource.Images, buildv1.ImageSource{
+			From: corev1.ObjectReference{
+				Kind: "DockerImage",
+				Name: source.Image,
+			},
+			Paths: paths,
+		})
+	}
+	for _, configMap := range cp.Spec.BuildConfigMaps {
+		buildSource.ConfigMaps = append(buildSource.ConfigMaps, buildv1.ConfigMapBuildSource{
+			ConfigMap:      corev1.LocalObjectReference{Name: configMap.Name},
+			DestinationDir: configMap.DestinationDir,
+		})
+	}
+	if cp.Spec.Dockerfile != "" {
+		dockerfile := cp.Spec.Dockerfile
+		buildSource.Dockerfile = &dockerfile
 	}
 	if secret != nil {
 		buildSource.SourceSecret = &corev1.LocalObjectReference{
 			Name: secret.Name,
 		}
 	}
+	// S2I builds are incremental unless the component asks for clean builds
 	incremental := true
+	if cp.Spec.Incremental != nil {
+		incremental = *cp.Spec.Incremental
+	}
+	triggers := []buildv1.BuildTriggerPolicy{
+		{
+			Type: "ConfigChange",
+		}, {
+			Type:        "ImageChange",
+			ImageChange: &buildv1.ImageChangeTrigger{},
+		},
+	}
+	// webhooks of the git providers start builds when they are called with the key of the referenced secret
+	if secretName := webhookSecretName(cp); secretName != "" {
+		secretRef := &buildv1.SecretLocalReference{Name: secretName}
+		triggers = append(triggers, buildv1.BuildTriggerPolicy{
+			Type:          buildv1.GitHubWebHookBuildTriggerType,
+			GitHubWebHook: &buildv1.WebHookTrigger{SecretReference: secretRef},
+		}, buildv1.BuildTriggerPolicy{
+			Type:          buildv1.GitLabWebHookBuildTriggerType,
+			GitLabWebHook: &buildv1.WebHookTrigger{SecretReference: secretRef},
+		}, buildv1.BuildTriggerPolicy{
+			Type:           buildv1.GenericWebHookBuildTriggerType,
+			GenericWebHook: &buildv1.WebHookTrigger{SecretReference: secretRef},
+		})
+	}
+	// while a pre-built image is deployed or builds are paused, the BuildConfig is only ready for builds started on demand
+	if cp.Spec.Image != "" || cp.Spec.PauseBuilds {
+		triggers = nil
+	}
+	// on clusters mixing architectures, the build runs on a node of the platform the image is built for
+	var nodeSelector buildv1.OptionalNodeSelector
+	if cp.Spec.BuildPlatform != "" {
+		nodeSelector = buildv1.OptionalNodeSelector{archNodeLabel: cp.Spec.BuildPlatform}
+	}
+	var imageLabels []buildv1.ImageLabel
+	for _, name := range sortedKeys(cp.Spec.OutputImageLabels) {
+		imageLabels = append(imageLabels, buildv1.ImageLabel{Name: name, Value: cp.Spec.OutputImageLabels[name]})
+	}
+	strategy := buildv1.BuildStrategy{
+		SourceStrategy: &buildv1.SourceBuildStrategy{
+			From:        builder,
+			Env:         cp.Spec.BuildEnv,
+			Incremental: &incremental,
+		},
+	}
+	// a docker build starts from the base image of the Dockerfile rather than from the builder image
+	if buildStrategy(cp) == buildStrategyDocker {
+		strategy = buildv1.BuildStrategy{
+			DockerStrategy: &buildv1.DockerBuildStrategy{
+				Env: cp.Spec.BuildEnv,
+			},
+		}
+	}
 	return &buildv1.BuildConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: cp.Namespace, Labels: labels, Annotations: annotations},
 		Spec: buildv1.BuildConfigSpec{
 			CommonSpec: buildv1.CommonSpec{
+				Resources:    newBuildResources(cp),
+				NodeSelector: nodeSelector,
 				Output: buildv1.BuildOutput{
 					To: &corev1.ObjectReference{
-						Kind: "ImageStreamTag",
-						Name: cp.Name + ":latest",
-					},
-				},
-				Source: buildSource,
-				Strategy: buildv1.BuildStrategy{
-					SourceStrategy: &buildv1.SourceBuildStrategy{
-						From: corev1.ObjectReference{
-							Kind:      "ImageStreamTag",
-							Name:      builder.Name + ":latest",
-							Namespace: builder.Namespace,
-						},
-						Incremental: &incremental,
+						Kind:      "ImageStreamTag",
+						Name:      cp.Name + ":" + outputTag(cp),
+						Namespace: outputNamespace(cp),
 					},
+					ImageLabels: imageLabels,
 				},
+				Source:   buildSource,
+				Strategy: strategy,
 			},
-			Triggers: []buildv1.BuildTriggerPolicy{
-				{
-					Type: "ConfigChange",
-				}, {
-					Type:        "ImageChange",
-					ImageChange: &buildv1.ImageChangeTrigger{},
-				},
-			},
+			Triggers: triggers,
 		},
 	}
 }
 
-func newDeploymentConfig(cp *devconsoleapi.Component, output *imagev1.ImageStream, containerPorts []corev1.ContainerPort) *v1.DeploymentConfig {
-	labels := resource.GetLabelsForCR(cp)
-	annotations := resource.GetAnnotationsForCR(cp)
+func newDeploymentConfig(cp *devconsoleapi.Component, output *imagev1.ImageStream, containerPorts []corev1.ContainerPort, limitRequestRatio float64) *v1.DeploymentConfig {
+	labels := componentLabels(cp)
+	annotations := componentAnnotations(cp)
 	if containerPorts == nil {
 		containerPorts = []corev1.ContainerPort{{
-			ContainerPort: 8080,
+			ContainerPort: defaultPort,
 			Protocol:      corev1.ProtocolTCP,
 		}}
 	}
+	triggers := []v1.DeploymentTriggerPolicy{{
+		Type: v1.DeploymentTriggerOnConfigChange,
+	}}
+	// without an output ImageStream the external image is deployed as is and there is no tag to watch, nor is there
+	// while a pre-built image is deployed ahead of the first build
+	image := cp.Spec.Image
+	if output != nil && cp.Spec.Image == "" && pinnedImage(cp) != "" {
+		// the pinned image is deployed whatever is built later, hence no tag to watch either
+		image = pinnedImage(cp)
+	} else if output != nil && cp.Spec.Image == "" {
+		image = output.Name + ":" + outputTag(cp)
+		triggers = append(triggers, v1.DeploymentTriggerPolicy{
+			Type: v1.DeploymentTriggerOnImageChange,
+			ImageChangeParams: &v1.DeploymentTriggerImageChangeParams{
+				Automatic: true,
+				ContainerNames: []string{
+					cp.Name,
+				},
+				From: corev1.ObjectReference{
+					Kind:      "ImageStreamTag",
+					Name:      output.Name + ":" + outputTag(cp),
+					Namespace: output.Namespace,
+				},
+			},
+		})
+	}
+	container := corev1.Container{
+		Name:           cp.Name,
+		Image:          image,
+		Ports:          containerPorts,
+		EnvFrom:        cp.Spec.EnvFrom,
+		ReadinessProbe: newProbe(cp.Spec.ReadinessProbe, containerPorts[0].ContainerPort, 0),
+		LivenessProbe:  newProbe(cp.Spec.LivenessProbe, containerPorts[0].ContainerPort, livenessInitialDelaySeconds),
+	}
+	if len(cp.Spec.Env) > 0 {
+		container.Env = cp.Spec.Env
+	}
+	// invalid quantities are rejected before the DeploymentConfig is reconciled
+	container.Resources, _ = newContainerResources(cp, limitRequestRatio)
+	var volumes []corev1.Volume
+	for _, volume := range cp.Spec.Volumes {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volume.Name, MountPath: volume.MountPath})
+		volumes = append(volumes, corev1.Volume{
+			Name: volume.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName(cp, volume)},
+			},
+		})
+	}
+	if cp.Spec.PreStop != nil {
+		container.Lifecycle = &corev1.Lifecycle{
+			PreStop: cp.Spec.PreStop,
+		}
+	}
+	var replicas int32 = 1
+	if cp.Spec.Replicas > 0 {
+		replicas = cp.Spec.Replicas
+	}
+	// when autoscaling, start from the autoscaler's minimum so that both agree on the initial size
+	if autoscalingEnabled(cp) {
+		replicas = 1
+		if cp.Spec.MinReplicas > 1 {
+			replicas = cp.Spec.MinReplicas
+		}
+	}
+	podSpec := corev1.PodSpec{
+		Containers:                   []corev1.Container{container},
+		Volumes:                      volumes,
+		AutomountServiceAccountToken: cp.Spec.AutomountServiceAccountToken,
+	}
+	for _, name := range cp.Spec.ImagePullSecrets {
+		podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+	if cp.Spec.RunAsUser != nil || cp.Spec.RunAsGroup != nil || cp.Spec.FSGroup != nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{
+			RunAsUser:  cp.Spec.RunAsUser,
+			RunAsGroup: cp.Spec.RunAsGroup,
+			FSGroup:    cp.Spec.FSGroup,
+		}
+	}
+	// spread replicas across nodes unless the user opted out
+	multiReplicas := replicas > 1 || (autoscalingEnabled(cp) && cp.Spec.MaxReplicas > 1)
+	if multiReplicas && !cp.Spec.DisableAntiAffinity {
+		podSpec.Affinity = newPodAntiAffinity(resource.GetLabelsForCR(cp))
+	}
+	strategy := v1.DeploymentStrategy{
+		Type: v1.DeploymentStrategyTypeRecreate,
+	}
+	// a DeploymentConfig has no progress deadline: its rollout fails once its strategy times out instead, hence the
+	// progress deadline of the component sets the timeout of the strategy
+	var timeout *int64
+	if cp.Spec.ProgressDeadlineSeconds != nil {
+		seconds := int64(*cp.Spec.ProgressDeadlineSeconds)
+		timeout = &seconds
+	}
+	if cp.Spec.DeployStrategy == deployStrategyRolling {
+		// the defaults of OpenShift, keeping most of the pods serving while a new version rolls out
+		maxUnavailable, maxSurge := intstr.FromString("25%"), intstr.FromString("25%")
+		updatePeriodSeconds, intervalSeconds := int64(1), int64(1)
+		strategy.Type = v1.DeploymentStrategyTypeRolling
+		strategy.RollingParams = &v1.RollingDeploymentStrategyParams{
+			UpdatePeriodSeconds: &updatePeriodSeconds,
+			IntervalSeconds:     &intervalSeconds,
+			TimeoutSeconds:      timeout,
+			MaxUnavailable:      &maxUnavailable,
+			MaxSurge:            &maxSurge,
+		}
+	} else if timeout != nil {
+		strategy.RecreateParams = &v1.RecreateDeploymentStrategyParams{
+			TimeoutSeconds: timeout,
+		}
+	}
+	// any change of the configuration changes the pod template, which the ConfigChange trigger rolls out
+	templateAnnotations := map[string]string{configHashAnnotation: configHash(cp)}
+	for key, value := range annotations {
+		templateAnnotations[key] = value
+	}
+	// a custom selector replaces the labels managed by the operator, which the pods carry along with the selected ones
+	selector, podLabels := resource.GetLabelsForCR(cp), labels
+	if len(cp.Spec.Selector) > 0 {
+		selector = cp.Spec.Selector
+		podLabels = map[string]string{}
+		for key, value := range labels {
+			podLabels[key] = value
+		}
+		podLabels, _ = mergeLabels(podLabels, cp.Spec.Selector)
+	}
 	return &v1.DeploymentConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        cp.Name,
@@ -124,53 +409,99 @@ func newDeploymentConfig(cp *devconsoleapi.Component, output *imagev1.ImageStrea
 			Annotations: annotations,
 		},
 		Spec: v1.DeploymentConfigSpec{
-			Strategy: v1.DeploymentStrategy{
-				Type: v1.DeploymentStrategyTypeRecreate,
-			},
-			Replicas: 1,
-			Selector: labels,
+			Strategy: strategy,
+			Replicas: replicas,
+			Selector: selector,
 			Template: &corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        cp.Name,
 					Namespace:   cp.Namespace,
-					Labels:      labels,
-					Annotations: annotations,
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{{
-						Name:  output.Name,
-						Image: output.Name + ":latest",
-						Ports: containerPorts,
-					},
-					},
-				},
-			},
-			Triggers: []v1.DeploymentTriggerPolicy{{
-				Type: v1.DeploymentTriggerOnConfigChange,
-			}, {
-				Type: v1.DeploymentTriggerOnImageChange,
-				ImageChangeParams: &v1.DeploymentTriggerImageChangeParams{
-					Automatic: true,
-					ContainerNames: []string{
-						output.Name,
-					},
-					From: corev1.ObjectReference{
-						Kind: "ImageStreamTag",
-						Name: output.Name + ":latest",
-					},
+					Labels:      podLabels,
+					Annotations: templateAnnotations,
 				},
+				Spec: podSpec,
 			},
-			},
+			Triggers: triggers,
 		},
 	}
 }
 
-func newService(cp *devconsoleapi.Component, port int32) (*corev1.Service, error) {
-	labels := resource.GetLabelsForCR(cp)
-	annotations := resource.GetAnnotationsForCR(cp)
-	if port > 65536 || port < 1024 {
-		return nil, fmt.Errorf("port %d is out of range [1024-65535]", port)
+// validateSelector checks that the custom selector of the component is made of valid labels which do not
+// conflict with the labels of the component, as its pods carry both.
+func validateSelector(cp *devconsoleapi.Component) error {
+	labels := componentLabels(cp)
+	for _, key := range sortedKeys(cp.Spec.Selector) {
+		value := cp.Spec.Selector[key]
+		problems := append(validation.IsQualifiedName(key), validation.IsValidLabelValue(value)...)
+		if current, ok := labels[key]; ok && current != value {
+			problems = append(problems, fmt.Sprintf("conflicts with the label %s=%s of the component", key, current))
+		}
+		if len(problems) > 0 {
+			err := fmt.Errorf("invalid selector %s=%s: %s", key, value, strings.Join(problems, ", "))
+			return withReason("InvalidSelector", newTerminalError(err))
+		}
+	}
+	return nil
+}
+
+// sharedBuilderImageStream tells whether the builder ImageStream of the component is the one of its build type,
+// shared by the components of the namespace, rather than one of its own.
+func sharedBuilderImageStream(cp *devconsoleapi.Component) bool {
+	return cp.Spec.BuilderImage == "" && cp.Spec.BuildTypeVersion == "" && !cp.Spec.DedicatedBuilderImageStream
+}
+
+// prebuilt tells whether the component deploys its pre-built image directly, without building it nor creating an
+// output ImageStream.
+func prebuilt(cp *devconsoleapi.Component) bool {
+	return cp.Spec.SkipOutputImageStream || cp.Spec.BuildType == buildTypeNone
+}
+
+// builderImage returns the image imported by the builder ImageStream of the component: its own builder image, or
+// else the image of its build type, whose tag is replaced by the requested build type version, if any.
+func builderImage(cp *devconsoleapi.Component) string {
+	if cp.Spec.BuilderImage != "" {
+		return cp.Spec.BuilderImage
+	}
+	image := buildTypeImages[cp.Spec.BuildType]
+	if image == "" || cp.Spec.BuildTypeVersion == "" {
+		return image
 	}
+	// the registry host of the image may have a port, the tag follows the last path segment
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + ":" + cp.Spec.BuildTypeVersion
+}
+
+// componentLabels returns the labels of the resources generated for the component: the labels of its spec, which
+// the labels managed by the operator override.
+func componentLabels(cp *devconsoleapi.Component) map[string]string {
+	labels := map[string]string{}
+	for key, value := range cp.Spec.Labels {
+		labels[key] = value
+	}
+	for key, value := range resource.GetLabelsForCR(cp) {
+		labels[key] = value
+	}
+	return labels
+}
+
+// componentAnnotations returns the annotations of the resources generated for the component: the annotations of
+// its spec, which the annotations managed by the operator override.
+func componentAnnotations(cp *devconsoleapi.Component) map[string]string {
+	annotations := map[string]string{}
+	for key, value := range cp.Spec.Annotations {
+		annotations[key] = value
+	}
+	for key, value := range resource.GetAnnotationsForCR(cp) {
+		annotations[key] = value
+	}
+	return annotations
+}
+
+func newService(cp *devconsoleapi.Component, port int32) *corev1.Service {
+	labels := componentLabels(cp)
+	annotations := componentAnnotations(cp)
 	var svcPorts []corev1.ServicePort
 	svcPort := corev1.ServicePort{
 		Name:       cp.Name + "-tcp",
@@ -188,17 +519,16 @@ func newService(cp *devconsoleapi.Component, port int32) (*corev1.Service, error
 		},
 		Spec: corev1.ServiceSpec{
 			Ports: svcPorts,
-			Selector: map[string]string{
-				"deploymentconfig": cp.Name,
-			},
+			// same selector as the DeploymentConfig, so that the service keeps selecting its pods whatever manages them
+			Selector: resource.GetLabelsForCR(cp),
 		},
 	}
-	return svc, nil
+	return svc
 }
 
-func newRoute(cp *devconsoleapi.Component) *routev1.Route {
-	labels := resource.GetLabelsForCR(cp)
-	annotations := resource.GetAnnotationsForCR(cp)
+func newRoute(cp *devconsoleapi.Component, port int32) *routev1.Route {
+	labels := componentLabels(cp)
+	annotations := componentAnnotations(cp)
 	route := &routev1.Route{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        cp.Name,
@@ -212,7 +542,7 @@ func newRoute(cp *devconsoleapi.Component) *routev1.Route {
 				Name: cp.Name,
 			},
 			Port: &routev1.RoutePort{
-				TargetPort: intstr.IntOrString{IntVal: cp.Spec.Port, StrVal: fmt.Sprintf("%d-tcp", cp.Spec.Port)},
+				TargetPort: intstr.IntOrString{IntVal: port, StrVal: fmt.Sprintf("%d-tcp", port)},
 			},
 		},
 	}
@@ -228,3 +558,180 @@ func newSecret(cp *devconsoleapi.Component, gitSource *devconsoleapi.GitSource)
 	}
 	return secret
 }
+
+// claimName returns the name of the PersistentVolumeClaim mounted as the given volume of the component.
+func claimName(cp *devconsoleapi.Component, volume devconsoleapi.VolumeSpec) string {
+	if volume.ClaimName != "" {
+		return volume.ClaimName
+	}
+	return cp.Name + "-" + volume.Name
+}
+
+// newPersistentVolumeClaim returns the claim of the given volume of the component, mounted by a single node at a
+// time. Invalid sizes are rejected before the claims are created.
+func newPersistentVolumeClaim(cp *devconsoleapi.Component, volume devconsoleapi.VolumeSpec) *corev1.PersistentVolumeClaim {
+	size := volume.Size
+	if size == "" {
+		size = defaultVolumeSize
+	}
+	storage, _ := apiresource.ParseQuantity(size)
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        claimName(cp, volume),
+			Namespace:   cp.Namespace,
+			Labels:      componentLabels(cp),
+			Annotations: componentAnnotations(cp),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: storage},
+			},
+		},
+	}
+}
+
+// newBuildResources returns the resources of the build pod: the defaults of the build type, overridden by the
+// limits and requests set in the component spec.
+func newBuildResources(cp *devconsoleapi.Component) corev1.ResourceRequirements {
+	defaults := buildTypeResources[cp.Spec.BuildType]
+	resources := corev1.ResourceRequirements{
+		Limits:   mergeResourceLists(defaults.Limits, nil),
+		Requests: mergeResourceLists(defaults.Requests, nil),
+	}
+	if cp.Spec.BuildResources != nil {
+		resources.Limits = mergeResourceLists(resources.Limits, cp.Spec.BuildResources.Limits)
+		resources.Requests = mergeResourceLists(resources.Requests, cp.Spec.BuildResources.Requests)
+	}
+	return resources
+}
+
+// mergeResourceLists returns a copy of defaults where the quantities set in overrides win.
+func mergeResourceLists(defaults, overrides corev1.ResourceList) corev1.ResourceList {
+	if len(defaults) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := corev1.ResourceList{}
+	for name, quantity := range defaults {
+		merged[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range overrides {
+		merged[name] = quantity.DeepCopy()
+	}
+	return merged
+}
+
+// gitRef returns the git ref to build: the one of the component when set, otherwise the one of the GitSource.
+// A ref read from a ConfigMap has already been resolved into the GitSource and takes precedence.
+func gitRef(cp *devconsoleapi.Component, gitSource *devconsoleapi.GitSource) string {
+	if cp.Spec.GitRef != "" && cp.Spec.GitRefFrom == nil {
+		return cp.Spec.GitRef
+	}
+	return gitSource.Spec.Ref
+}
+
+// webhookSecretName returns the name of the Secret securing the webhooks of the component: the referenced one, else
+// the one generated when webhooks are enabled, or "" when builds are not triggered by webhooks.
+func webhookSecretName(cp *devconsoleapi.Component) string {
+	if cp.Spec.WebhookSecretRef != "" {
+		return cp.Spec.WebhookSecretRef
+	}
+	if cp.Spec.EnableWebhooks {
+		return cp.Name + "-webhook"
+	}
+	return ""
+}
+
+// newWebhookSecret returns the Secret holding the random key which secures the webhooks of the component.
+func newWebhookSecret(cp *devconsoleapi.Component) (*corev1.Secret, error) {
+	key := make([]byte, 20)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        webhookSecretName(cp),
+			Namespace:   cp.Namespace,
+			Labels:      componentLabels(cp),
+			Annotations: componentAnnotations(cp),
+		},
+		Data: map[string][]byte{
+			webhookSecretKey: []byte(hex.EncodeToString(key)),
+		},
+	}, nil
+}
+
+// webhookURLs returns the URLs of the webhooks starting the builds of the BuildConfig. The key of the secret is
+// left out as a <secret> placeholder, as everyone allowed to read the component is not allowed to start builds.
+func webhookURLs(bc *buildv1.BuildConfig, apiServerURL string) []string {
+	var urls []string
+	for _, trigger := range bc.Spec.Triggers {
+		switch trigger.Type {
+		case buildv1.GitHubWebHookBuildTriggerType, buildv1.GitLabWebHookBuildTriggerType, buildv1.GenericWebHookBuildTriggerType:
+			urls = append(urls, fmt.Sprintf("%s/apis/build.openshift.io/v1/namespaces/%s/buildconfigs/%s/webhooks/<secret>/%s",
+				apiServerURL, bc.Namespace, bc.Name, strings.ToLower(string(trigger.Type))))
+		}
+	}
+	return urls
+}
+
+// conventionalSourceSecretName returns the name of the source secret looked up when the GitSource does not reference
+// one.
+func conventionalSourceSecretName(cp *devconsoleapi.Component) string {
+	return cp.Name + "-git-secret"
+}
+
+// newPodAntiAffinity returns a soft anti-affinity which prefers scheduling the pods matching the given labels
+// on different nodes.
+func newPodAntiAffinity(labels map[string]string) *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: labels,
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			}},
+		},
+	}
+}
+
+// autoscalingEnabled returns true when the component's replicas are managed by a HorizontalPodAutoscaler
+// rather than by the operator.
+func autoscalingEnabled(cp *devconsoleapi.Component) bool {
+	return cp.Spec.MaxReplicas > 0
+}
+
+// newHorizontalPodAutoscaler returns the autoscaler scaling the DeploymentConfig of the component between its
+// minimum and maximum replicas. The defaults of Kubernetes are set explicitly, so that the autoscaler read back
+// compares equal to the desired one.
+func newHorizontalPodAutoscaler(cp *devconsoleapi.Component) *autoscalingv1.HorizontalPodAutoscaler {
+	minReplicas, targetCPU := int32(1), defaultTargetCPUPercent
+	if cp.Spec.MinReplicas > 0 {
+		minReplicas = cp.Spec.MinReplicas
+	}
+	if cp.Spec.TargetCPUPercent > 0 {
+		targetCPU = cp.Spec.TargetCPUPercent
+	}
+	return &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cp.Name,
+			Namespace:   cp.Namespace,
+			Labels:      componentLabels(cp),
+			Annotations: componentAnnotations(cp),
+		},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       "DeploymentConfig",
+				Name:       cp.Name,
+			},
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    cp.Spec.MaxReplicas,
+			TargetCPUUtilizationPercentage: &targetCPU,
+		},
+	}
+}
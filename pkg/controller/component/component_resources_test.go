@@ -0,0 +1,1621 @@
+package component
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newTestComponent(spec devconsoleapi.ComponentSpec) *devconsoleapi.Component {
+	return &devconsoleapi.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: Namespace,
+		},
+		Spec: spec,
+	}
+}
+
+func newTestOutputImageStream() *imagev1.ImageStream {
+	return &imagev1.ImageStream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: Namespace,
+		},
+	}
+}
+
+// newTestResolvedOutputImageStream returns an output ImageStream to which a build already pushed an image.
+func newTestResolvedOutputImageStream() *imagev1.ImageStream {
+	is := newTestOutputImageStream()
+	is.Status.Tags = []imagev1.NamedTagEventList{{
+		Tag: "latest",
+		Items: []imagev1.TagEvent{{
+			Image: "sha256:4f6a1b2c",
+		}},
+	}}
+	return is
+}
+
+func newTestBuilderImageStreamTag() corev1.ObjectReference {
+	return corev1.ObjectReference{
+		Kind:      "ImageStreamTag",
+		Name:      "nodejs:latest",
+		Namespace: "openshift",
+	}
+}
+
+func newTestGitSource() *devconsoleapi.GitSource {
+	return &devconsoleapi.GitSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-git-source",
+			Namespace: Namespace,
+		},
+		Spec: devconsoleapi.GitSourceSpec{
+			URL: "https://github.com/test/example",
+			Ref: "master",
+		},
+	}
+}
+
+func TestNewBuildConfig(t *testing.T) {
+	t.Run("with output image labels", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			OutputImageLabels: map[string]string{
+				"org.opencontainers.image.vendor": "Example",
+				"org.opencontainers.image.source": "https://github.com/test/example",
+			},
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, []buildv1.ImageLabel{
+			{Name: "org.opencontainers.image.source", Value: "https://github.com/test/example"},
+			{Name: "org.opencontainers.image.vendor", Value: "Example"},
+		}, bc.Spec.Output.ImageLabels, "output image labels should be applied in a stable order")
+	})
+
+	t.Run("with image sources", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			ImageSources: []devconsoleapi.ImageSource{{
+				Image: "quay.io/example/assets:latest",
+				Paths: []devconsoleapi.ImageSourcePath{{
+					SourcePath:     "/opt/assets/.",
+					DestinationDir: "public",
+				}},
+			}},
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, []buildv1.ImageSource{{
+			From: corev1.ObjectReference{
+				Kind: "DockerImage",
+				Name: "quay.io/example/assets:latest",
+			},
+			Paths: []buildv1.ImageSourcePath{{
+				SourcePath:     "/opt/assets/.",
+				DestinationDir: "public",
+			}},
+		}}, bc.Spec.Source.Images, "image sources should be injected into the build")
+	})
+
+	t.Run("without output image labels", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Nil(t, bc.Spec.Output.ImageLabels, "no output image labels should be set")
+	})
+
+	t.Run("with build type default resources", func(t *testing.T) {
+		//given
+		java := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "java",
+			GitSourceRef: "my-git-source",
+		})
+		nodejs := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		javaBc := newBuildConfig(java, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+		nodejsBc := newBuildConfig(nodejs, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		javaMemory := javaBc.Spec.Resources.Limits[corev1.ResourceMemory]
+		nodejsMemory := nodejsBc.Spec.Resources.Limits[corev1.ResourceMemory]
+		require.Equal(t, "2Gi", javaMemory.String(), "java builds should get more memory by default")
+		require.Equal(t, "512Mi", nodejsMemory.String(), "nodejs builds should get less memory by default")
+	})
+
+	t.Run("with build resources overriding the build type defaults", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "java",
+			GitSourceRef: "my-git-source",
+			BuildResources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+					corev1.ResourceCPU:    resource.MustParse("2"),
+				},
+			},
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		memory := bc.Spec.Resources.Limits[corev1.ResourceMemory]
+		cpu := bc.Spec.Resources.Limits[corev1.ResourceCPU]
+		requestedMemory := bc.Spec.Resources.Requests[corev1.ResourceMemory]
+		require.Equal(t, "4Gi", memory.String(), "memory limit of the spec should win")
+		require.Equal(t, "2", cpu.String(), "cpu limit of the spec should be added")
+		require.Equal(t, "1Gi", requestedMemory.String(), "requests not set in the spec should keep their default")
+	})
+
+	t.Run("with an unknown build type", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "php",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, corev1.ResourceRequirements{}, bc.Spec.Resources, "build resources should be left to the cluster")
+	})
+
+	t.Run("with an explicit git ref", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			GitRef:       "main",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, "main", bc.Spec.Source.Git.Ref, "git ref of the component should be built")
+	})
+
+	t.Run("without a git ref", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, "master", bc.Spec.Source.Git.Ref, "git ref of the GitSource should be built")
+	})
+
+	t.Run("with a build platform", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:     "nodejs",
+			GitSourceRef:  "my-git-source",
+			BuildPlatform: "arm64",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, buildv1.OptionalNodeSelector{"beta.kubernetes.io/arch": "arm64"}, bc.Spec.NodeSelector, "build should be scheduled on a node of the build platform")
+	})
+
+	t.Run("without a build platform", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Nil(t, bc.Spec.NodeSelector, "build should be scheduled on any node")
+	})
+
+	t.Run("with a context dir", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			ContextDir:   "services/api",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, "services/api", bc.Spec.Source.ContextDir, "build should run from the context dir")
+	})
+
+	t.Run("without a context dir", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Empty(t, bc.Spec.Source.ContextDir, "build should run from the repository root")
+	})
+
+	t.Run("with the docker build strategy", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:     "nodejs",
+			GitSourceRef:  "my-git-source",
+			BuildStrategy: "docker",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.NotNil(t, bc.Spec.Strategy.DockerStrategy, "build should use the docker strategy")
+		require.Nil(t, bc.Spec.Strategy.SourceStrategy, "build should not use the source strategy")
+	})
+
+	t.Run("without a build strategy", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.NotNil(t, bc.Spec.Strategy.SourceStrategy, "build should default to the source strategy")
+		require.Nil(t, bc.Spec.Strategy.DockerStrategy, "build should not use the docker strategy")
+	})
+
+	t.Run("with an inline Dockerfile", func(t *testing.T) {
+		//given
+		dockerfile := "FROM centos:7\nCOPY . /app"
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:     "nodejs",
+			GitSourceRef:  "my-git-source",
+			BuildStrategy: "docker",
+			Dockerfile:    dockerfile,
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.NotNil(t, bc.Spec.Source.Dockerfile, "build source should get the inline Dockerfile")
+		require.Equal(t, dockerfile, *bc.Spec.Source.Dockerfile)
+	})
+
+	t.Run("without an inline Dockerfile", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:     "nodejs",
+			GitSourceRef:  "my-git-source",
+			BuildStrategy: "docker",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Nil(t, bc.Spec.Source.Dockerfile, "build should use the Dockerfile of the repository")
+	})
+
+	t.Run("with build config maps", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			BuildConfigMaps: []devconsoleapi.BuildConfigMap{
+				{Name: "npmrc"},
+				{Name: "maven-settings", DestinationDir: ".m2"},
+			},
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, []buildv1.ConfigMapBuildSource{
+			{ConfigMap: corev1.LocalObjectReference{Name: "npmrc"}},
+			{ConfigMap: corev1.LocalObjectReference{Name: "maven-settings"}, DestinationDir: ".m2"},
+		}, bc.Spec.Source.ConfigMaps, "config maps should be mounted into the build")
+	})
+
+	t.Run("with a webhook secret", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:        "nodejs",
+			GitSourceRef:     "my-git-source",
+			WebhookSecretRef: "my-webhook-secret",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		secretRef := &buildv1.SecretLocalReference{Name: "my-webhook-secret"}
+		require.Contains(t, bc.Spec.Triggers, buildv1.BuildTriggerPolicy{
+			Type:          buildv1.GitHubWebHookBuildTriggerType,
+			GitHubWebHook: &buildv1.WebHookTrigger{SecretReference: secretRef},
+		}, "github webhook should use the referenced secret")
+		require.Contains(t, bc.Spec.Triggers, buildv1.BuildTriggerPolicy{
+			Type:          buildv1.GitLabWebHookBuildTriggerType,
+			GitLabWebHook: &buildv1.WebHookTrigger{SecretReference: secretRef},
+		}, "gitlab webhook should use the referenced secret")
+		require.Contains(t, bc.Spec.Triggers, buildv1.BuildTriggerPolicy{
+			Type:           buildv1.GenericWebHookBuildTriggerType,
+			GenericWebHook: &buildv1.WebHookTrigger{SecretReference: secretRef},
+		}, "generic webhook should use the referenced secret")
+	})
+
+	t.Run("without a webhook secret", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, []buildv1.BuildTriggerType{buildv1.ConfigChangeBuildTriggerType, buildv1.ImageChangeBuildTriggerType}, buildTriggerTypes(bc.Spec.Triggers), "builds should not be triggered by webhooks")
+	})
+
+	t.Run("with incremental builds", func(t *testing.T) {
+		enabled, disabled := true, false
+		for name, tc := range map[string]struct {
+			incremental *bool
+			expected    bool
+		}{
+			"unset":          {nil, true},
+			"explicit true":  {&enabled, true},
+			"explicit false": {&disabled, false},
+		} {
+			//given
+			cp := newTestComponent(devconsoleapi.ComponentSpec{
+				BuildType:    "nodejs",
+				GitSourceRef: "my-git-source",
+				Incremental:  tc.incremental,
+			})
+
+			//when
+			bc := newBuildConfig(cp, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+			//then
+			require.Equal(t, tc.expected, *bc.Spec.Strategy.SourceStrategy.Incremental, "unexpected incremental build when %s", name)
+		}
+	})
+
+	t.Run("with build env", func(t *testing.T) {
+		//given
+		buildEnv := []corev1.EnvVar{
+			{Name: "NODE_ENV", Value: "production"},
+			{Name: "NPM_TOKEN", ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "npm"},
+					Key:                  "token",
+				},
+			}},
+		}
+		s2i := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			BuildEnv:     buildEnv,
+		})
+		docker := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:     "nodejs",
+			GitSourceRef:  "my-git-source",
+			BuildStrategy: "docker",
+			BuildEnv:      buildEnv,
+		})
+
+		//when
+		s2iBC := newBuildConfig(s2i, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+		dockerBC := newBuildConfig(docker, newTestBuilderImageStreamTag(), newTestGitSource(), nil)
+
+		//then
+		require.Equal(t, buildEnv, s2iBC.Spec.Strategy.SourceStrategy.Env, "build env should be set on the source strategy")
+		require.Equal(t, buildEnv, dockerBC.Spec.Strategy.DockerStrategy.Env, "build env should be set on the docker strategy")
+	})
+}
+
+func TestNewDeploymentConfig(t *testing.T) {
+	t.Run("with a preStop hook", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			PreStop: &corev1.Handler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"sh", "-c", "sleep 10"},
+				},
+			},
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		container := dc.Spec.Template.Spec.Containers[0]
+		require.NotNil(t, container.Lifecycle, "container should have a lifecycle")
+		require.NotNil(t, container.Lifecycle.PreStop, "container should have a preStop hook")
+		require.Equal(t, []string{"sh", "-c", "sleep 10"}, container.Lifecycle.PreStop.Exec.Command)
+		require.Nil(t, container.Lifecycle.PostStart, "container should not have a postStart hook")
+	})
+
+	t.Run("with autoscaling starts from the autoscaler minimum", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			MinReplicas:  2,
+			MaxReplicas:  5,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, int32(2), dc.Spec.Replicas, "initial replicas should match the autoscaler minimum")
+	})
+
+	t.Run("without autoscaling runs a single replica", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			MinReplicas:  2,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, int32(1), dc.Spec.Replicas, "minReplicas should be ignored without maxReplicas")
+	})
+
+	t.Run("with multiple replicas spreads pods across nodes", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			MinReplicas:  2,
+			MaxReplicas:  5,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		affinity := dc.Spec.Template.Spec.Affinity
+		require.NotNil(t, affinity, "multi-replica component should have a default affinity")
+		require.NotNil(t, affinity.PodAntiAffinity, "multi-replica component should have a pod anti-affinity")
+		require.Empty(t, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, "default anti-affinity should be soft")
+		terms := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+		require.Len(t, terms, 1)
+		require.Equal(t, "kubernetes.io/hostname", terms[0].PodAffinityTerm.TopologyKey)
+		require.Equal(t, dc.Spec.Selector, terms[0].PodAffinityTerm.LabelSelector.MatchLabels, "anti-affinity should select the component's pods")
+	})
+
+	t.Run("with multiple replicas and anti-affinity disabled", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:           "nodejs",
+			GitSourceRef:        "my-git-source",
+			MinReplicas:         2,
+			MaxReplicas:         5,
+			DisableAntiAffinity: true,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Nil(t, dc.Spec.Template.Spec.Affinity, "anti-affinity should not be set when disabled")
+	})
+
+	t.Run("with a single replica", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Nil(t, dc.Spec.Template.Spec.Affinity, "single-replica component should not have an affinity")
+	})
+
+	t.Run("with a progress deadline", func(t *testing.T) {
+		//given
+		deadline := int32(300)
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:               "nodejs",
+			GitSourceRef:            "my-git-source",
+			ProgressDeadlineSeconds: &deadline,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.NotNil(t, dc.Spec.Strategy.RecreateParams, "strategy params should be set")
+		require.Equal(t, int64(300), *dc.Spec.Strategy.RecreateParams.TimeoutSeconds, "rollout should time out after the progress deadline")
+	})
+
+	t.Run("without a progress deadline", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Nil(t, dc.Spec.Strategy.RecreateParams, "default strategy timeout should be used")
+	})
+
+	t.Run("without a preStop hook", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Nil(t, dc.Spec.Template.Spec.Containers[0].Lifecycle, "container should not have a lifecycle")
+	})
+
+	t.Run("with a user, group and fsGroup", func(t *testing.T) {
+		//given
+		user, group, fsGroup := int64(1001), int64(1002), int64(1003)
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			RunAsUser:    &user,
+			RunAsGroup:   &group,
+			FSGroup:      &fsGroup,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		securityContext := dc.Spec.Template.Spec.SecurityContext
+		require.NotNil(t, securityContext, "pod security context should be set")
+		require.Equal(t, int64(1001), *securityContext.RunAsUser, "pods should run as the given user")
+		require.Equal(t, int64(1002), *securityContext.RunAsGroup, "pods should run as the given group")
+		require.Equal(t, int64(1003), *securityContext.FSGroup, "volumes should be owned by the given fsGroup")
+	})
+
+	t.Run("without a user, group nor fsGroup", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Nil(t, dc.Spec.Template.Spec.SecurityContext, "the cluster should pick the user of the pods")
+	})
+
+	t.Run("with image pull secrets", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:        "nodejs",
+			GitSourceRef:     "my-git-source",
+			ImagePullSecrets: []string{"quay-pull", "registry-pull"},
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, []corev1.LocalObjectReference{
+			{Name: "quay-pull"},
+			{Name: "registry-pull"},
+		}, dc.Spec.Template.Spec.ImagePullSecrets, "pull secrets should be set on the pod template")
+	})
+
+	t.Run("without container ports", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolTCP}}, dc.Spec.Template.Spec.Containers[0].Ports, "container should listen on the default port")
+	})
+
+	t.Run("with a custom container port", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Port:         3000,
+		})
+		ports := []corev1.ContainerPort{{ContainerPort: cp.Spec.Port, Protocol: corev1.ProtocolTCP}}
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), ports, 0)
+
+		//then
+		require.Equal(t, ports, dc.Spec.Template.Spec.Containers[0].Ports, "container should listen on the port of the component")
+	})
+
+	t.Run("with environment variables", func(t *testing.T) {
+		//given
+		env := []corev1.EnvVar{
+			{Name: "NODE_ENV", Value: "production"},
+			{Name: "LOG_LEVEL", Value: "debug"},
+		}
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Env:          env,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, env, dc.Spec.Template.Spec.Containers[0].Env, "container should get the environment variables of the component")
+	})
+
+	t.Run("with an empty list of environment variables", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Env:          []corev1.EnvVar{},
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Nil(t, dc.Spec.Template.Spec.Containers[0].Env, "container should not get an empty environment")
+	})
+
+	t.Run("with a changed environment variable", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Env:          []corev1.EnvVar{{Name: "NODE_ENV", Value: "development"}},
+		})
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+		cp.Spec.Env[0].Value = "production"
+
+		//when
+		changedDc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		hash := dc.Spec.Template.Annotations[configHashAnnotation]
+		require.NotEmpty(t, hash, "pod template should get the config hash")
+		require.NotEqual(t, hash, changedDc.Spec.Template.Annotations[configHashAnnotation], "config hash should change with the environment, which rolls the pods out")
+		require.NotContains(t, changedDc.Annotations, configHashAnnotation, "config hash should only be set on the pod template")
+	})
+
+	t.Run("with resource limits and requests", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:        "nodejs",
+			GitSourceRef:     "my-git-source",
+			ResourceLimits:   map[string]string{"cpu": "500m", "memory": "512Mi"},
+			ResourceRequests: map[string]string{"cpu": "100m", "memory": "256Mi"},
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		resources := dc.Spec.Template.Spec.Containers[0].Resources
+		require.Equal(t, resource.MustParse("500m"), resources.Limits[corev1.ResourceCPU], "container cpu limit should be parsed")
+		require.Equal(t, resource.MustParse("512Mi"), resources.Limits[corev1.ResourceMemory], "container memory limit should be parsed")
+		require.Equal(t, resource.MustParse("100m"), resources.Requests[corev1.ResourceCPU], "container cpu request should be parsed")
+		require.Equal(t, resource.MustParse("256Mi"), resources.Requests[corev1.ResourceMemory], "container memory request should be parsed")
+	})
+
+	t.Run("without resource limits nor requests", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, corev1.ResourceRequirements{}, dc.Spec.Template.Spec.Containers[0].Resources, "container should not be limited")
+	})
+
+	t.Run("without probes", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+		ports := []corev1.ContainerPort{{ContainerPort: 3000, Protocol: corev1.ProtocolTCP}}
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), ports, 0)
+
+		//then
+		container := dc.Spec.Template.Spec.Containers[0]
+		require.NotNil(t, container.ReadinessProbe, "container should have a readiness probe")
+		require.Equal(t, &corev1.TCPSocketAction{Port: intstr.FromInt(3000)}, container.ReadinessProbe.TCPSocket, "readiness should default to a TCP check of the container port")
+		require.NotNil(t, container.LivenessProbe, "container should have a liveness probe")
+		require.Equal(t, &corev1.TCPSocketAction{Port: intstr.FromInt(3000)}, container.LivenessProbe.TCPSocket, "liveness should default to a TCP check of the container port")
+		require.Equal(t, int32(30), container.LivenessProbe.InitialDelaySeconds, "container should have time to start before liveness is checked")
+	})
+
+	t.Run("with HTTP probes", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:      "nodejs",
+			GitSourceRef:   "my-git-source",
+			ReadinessProbe: &devconsoleapi.ProbeSpec{Path: "/ready"},
+			LivenessProbe:  &devconsoleapi.ProbeSpec{Path: "/healthz", Port: 9090},
+		})
+		ports := []corev1.ContainerPort{{ContainerPort: 3000, Protocol: corev1.ProtocolTCP}}
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), ports, 0)
+
+		//then
+		container := dc.Spec.Template.Spec.Containers[0]
+		require.Nil(t, container.ReadinessProbe.TCPSocket, "readiness should not check the TCP port")
+		require.Equal(t, &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt(3000)}, container.ReadinessProbe.HTTPGet, "readiness should get the path on the container port")
+		require.Equal(t, &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(9090)}, container.LivenessProbe.HTTPGet, "liveness should get the path on the given port")
+	})
+
+	t.Run("without mounting the service account token", func(t *testing.T) {
+		//given
+		automount := false
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:                    "nodejs",
+			GitSourceRef:                 "my-git-source",
+			AutomountServiceAccountToken: &automount,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.NotNil(t, dc.Spec.Template.Spec.AutomountServiceAccountToken, "pods should not mount the service account token")
+		require.False(t, *dc.Spec.Template.Spec.AutomountServiceAccountToken, "pods should not mount the service account token")
+	})
+
+	t.Run("without a service account token setting", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Nil(t, dc.Spec.Template.Spec.AutomountServiceAccountToken, "pods should follow the service account setting")
+	})
+
+	t.Run("with replicas", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Replicas:     3,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, int32(3), dc.Spec.Replicas, "deployment config should run the replicas of the component")
+		require.NotNil(t, dc.Spec.Template.Spec.Affinity, "replicas should be spread across nodes")
+	})
+
+	t.Run("without replicas", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, int32(1), dc.Spec.Replicas, "deployment config should default to a single replica")
+	})
+
+	t.Run("with a custom selector", func(t *testing.T) {
+		//given
+		selector := map[string]string{"service": "api", "tier": "backend"}
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Selector:     selector,
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, selector, dc.Spec.Selector, "deployment config should use the custom selector")
+		for key, value := range selector {
+			require.Equal(t, value, dc.Spec.Template.Labels[key], "pods should carry the selected label %s", key)
+		}
+		for key, value := range dc.Labels {
+			require.Equal(t, value, dc.Spec.Template.Labels[key], "pods should keep the label %s of the component", key)
+		}
+		require.NotContains(t, dc.Labels, "tier", "deployment config should not get the selected labels")
+	})
+
+	t.Run("without a custom selector", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, dc.Labels, dc.Spec.Selector, "deployment config should select the labels of the component")
+		require.Equal(t, dc.Labels, dc.Spec.Template.Labels, "pods should carry the labels of the component")
+	})
+
+	t.Run("with custom labels and annotations", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Labels:       map[string]string{"team": "payments", "app": "other"},
+			Annotations:  map[string]string{"cost-center": "42"},
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, "payments", dc.Labels["team"], "deployment config should get the custom label")
+		require.Equal(t, cp.Name, dc.Labels["app"], "operator label should win over the custom label")
+		require.Equal(t, "payments", dc.Spec.Template.Labels["team"], "pods should get the custom label")
+		require.NotContains(t, dc.Spec.Selector, "team", "custom label should not be selected")
+		require.Equal(t, "42", dc.Annotations["cost-center"], "deployment config should get the custom annotation")
+	})
+
+	t.Run("with a limit request ratio", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:        "nodejs",
+			GitSourceRef:     "my-git-source",
+			ResourceLimits:   map[string]string{"cpu": "1"},
+			ResourceRequests: map[string]string{"memory": "256Mi"},
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 2)
+
+		//then
+		resources := dc.Spec.Template.Spec.Containers[0].Resources
+		require.Equal(t, "512Mi", resources.Limits.Memory().String(), "memory limit should be derived from the request")
+		require.Equal(t, "500m", resources.Requests.Cpu().String(), "cpu request should be derived from the limit")
+		require.Equal(t, "1", resources.Limits.Cpu().String(), "cpu limit should be kept")
+		require.Equal(t, "256Mi", resources.Requests.Memory().String(), "memory request should be kept")
+	})
+
+	t.Run("with a deploy strategy", func(t *testing.T) {
+		for strategy, expected := range map[string]appsv1.DeploymentStrategyType{
+			"":         appsv1.DeploymentStrategyTypeRecreate,
+			"Recreate": appsv1.DeploymentStrategyTypeRecreate,
+			"Rolling":  appsv1.DeploymentStrategyTypeRolling,
+		} {
+			//given
+			deadline := int32(300)
+			cp := newTestComponent(devconsoleapi.ComponentSpec{
+				BuildType:               "nodejs",
+				GitSourceRef:            "my-git-source",
+				DeployStrategy:          strategy,
+				ProgressDeadlineSeconds: &deadline,
+			})
+
+			//when
+			dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+			//then
+			require.Equal(t, expected, dc.Spec.Strategy.Type, "unexpected strategy type for %q", strategy)
+			if expected == appsv1.DeploymentStrategyTypeRolling {
+				require.Nil(t, dc.Spec.Strategy.RecreateParams, "recreate params should not be set on a rolling strategy")
+				require.NotNil(t, dc.Spec.Strategy.RollingParams, "rolling params should be set")
+				require.Equal(t, "25%", dc.Spec.Strategy.RollingParams.MaxUnavailable.String())
+				require.Equal(t, "25%", dc.Spec.Strategy.RollingParams.MaxSurge.String())
+				require.Equal(t, int64(300), *dc.Spec.Strategy.RollingParams.TimeoutSeconds, "rollout should time out after the progress deadline")
+			} else {
+				require.Nil(t, dc.Spec.Strategy.RollingParams, "rolling params should not be set on a recreate strategy")
+				require.Equal(t, int64(300), *dc.Spec.Strategy.RecreateParams.TimeoutSeconds, "rollout should time out after the progress deadline")
+			}
+		}
+	})
+
+	t.Run("with a pinned image", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:        "nodejs",
+			GitSourceRef:     "my-git-source",
+			PinDeployedImage: true,
+		})
+		cp.Status.PinnedImage = "172.30.1.1:5000/" + Namespace + "/" + Name + "@sha256:4f6a1b2c"
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		require.Equal(t, cp.Status.PinnedImage, dc.Spec.Template.Spec.Containers[0].Image, "pinned image should be deployed")
+		require.Len(t, dc.Spec.Triggers, 1, "only config changes should be rolled out")
+		require.Equal(t, appsv1.DeploymentTriggerOnConfigChange, dc.Spec.Triggers[0].Type)
+	})
+
+	t.Run("with a volume mounts its claim", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Volumes:      []devconsoleapi.VolumeSpec{{Name: "data", MountPath: "/var/lib/data"}},
+		})
+
+		//when
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//then
+		podSpec := dc.Spec.Template.Spec
+		require.Len(t, podSpec.Volumes, 1)
+		require.Equal(t, "data", podSpec.Volumes[0].Name)
+		require.NotNil(t, podSpec.Volumes[0].PersistentVolumeClaim, "volume should be backed by a claim")
+		require.Equal(t, cp.Name+"-data", podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+		require.Equal(t, []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/data"}}, podSpec.Containers[0].VolumeMounts)
+	})
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("with the default port", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+		dc := newDeploymentConfig(cp, newTestOutputImageStream(), nil, 0)
+
+		//when
+		svc := newService(cp, 8080)
+
+		//then
+		require.Equal(t, Name, svc.Name, "service should be named after the component")
+		require.Equal(t, dc.Spec.Selector, svc.Spec.Selector, "service should select the pods of the deployment config")
+		require.Len(t, svc.Spec.Ports, 1, "service should expose one port")
+		require.Equal(t, int32(8080), svc.Spec.Ports[0].Port, "service should listen on 8080")
+		require.Equal(t, 8080, svc.Spec.Ports[0].TargetPort.IntValue(), "service should target the container port 8080")
+		require.Equal(t, corev1.ProtocolTCP, svc.Spec.Ports[0].Protocol, "service should use TCP")
+	})
+}
+
+func TestNewRoute(t *testing.T) {
+	t.Run("with the default port", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			Exposed:      true,
+		})
+
+		//when
+		route := newRoute(cp, 8080)
+
+		//then
+		require.Equal(t, "Service", route.Spec.To.Kind, "route should target a service")
+		require.Equal(t, Name, route.Spec.To.Name, "route should target the service of the component")
+		require.Equal(t, int32(8080), route.Spec.Port.TargetPort.IntVal, "route should target the service port")
+	})
+}
+
+func TestNewHorizontalPodAutoscaler(t *testing.T) {
+	t.Run("with bounds and CPU target", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:        "nodejs",
+			GitSourceRef:     "my-git-source",
+			MinReplicas:      2,
+			MaxReplicas:      5,
+			TargetCPUPercent: 60,
+		})
+
+		//when
+		hpa := newHorizontalPodAutoscaler(cp)
+
+		//then
+		require.Equal(t, autoscalingv1.CrossVersionObjectReference{APIVersion: "apps.openshift.io/v1", Kind: "DeploymentConfig", Name: cp.Name}, hpa.Spec.ScaleTargetRef)
+		require.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		require.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		require.Equal(t, int32(60), *hpa.Spec.TargetCPUUtilizationPercentage)
+	})
+
+	t.Run("with defaults", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			MaxReplicas:  3,
+		})
+
+		//when
+		hpa := newHorizontalPodAutoscaler(cp)
+
+		//then
+		require.Equal(t, int32(1), *hpa.Spec.MinReplicas, "minimum should default to a single replica")
+		require.Equal(t, int32(80), *hpa.Spec.TargetCPUUtilizationPercentage, "CPU target should default to the Kubernetes one")
+	})
+}
+
+func TestNewImageStreamFromDocker(t *testing.T) {
+	t.Run("with a known build type", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		is := newImageStreamFromDocker(cp)
+
+		//then
+		require.Equal(t, "nodejs", is.Name, "builder imagestream should be named after the build type")
+		require.Equal(t, "nodeshift/centos7-s2i-nodejs:10.x", is.Spec.Tags[0].From.Name, "builder imagestream should import the image of the build type")
+	})
+
+	t.Run("with a builder image overriding a known build type", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+			BuilderImage: "quay.io/example/nodejs-s2i:12",
+		})
+
+		//when
+		is := newImageStreamFromDocker(cp)
+
+		//then
+		require.Equal(t, Name+"-builder", is.Name, "builder imagestream should be owned by the component alone")
+		require.Equal(t, "quay.io/example/nodejs-s2i:12", is.Spec.Tags[0].From.Name, "builder image of the component should take precedence")
+	})
+
+	t.Run("with an unknown build type", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "rust",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		is := newImageStreamFromDocker(cp)
+
+		//then
+		require.Nil(t, is, "no builder image is known for the build type")
+	})
+
+	t.Run("with scheduled imports", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:       "nodejs",
+			GitSourceRef:    "my-git-source",
+			ScheduledImport: true,
+		})
+
+		//when
+		is := newImageStreamFromDocker(cp)
+
+		//then
+		require.True(t, is.Spec.Tags[0].ImportPolicy.Scheduled, "builder image should be re-imported periodically")
+	})
+
+	t.Run("without scheduled imports", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:    "nodejs",
+			GitSourceRef: "my-git-source",
+		})
+
+		//when
+		is := newImageStreamFromDocker(cp)
+
+		//then
+		require.False(t, is.Spec.Tags[0].ImportPolicy.Scheduled, "builder image should be imported once")
+	})
+
+	t.Run("with a build type version overriding the default tag", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:        "nodejs",
+			GitSourceRef:     "my-git-source",
+			BuildTypeVersion: "12.x",
+		})
+
+		//when
+		is := newImageStreamFromDocker(cp)
+
+		//then
+		require.Equal(t, Name+"-builder", is.Name, "builder imagestream of another version should be owned by the component alone")
+		require.Equal(t, "nodeshift/centos7-s2i-nodejs:12.x", is.Spec.Tags[0].From.Name, "builder imagestream should import the requested version")
+	})
+}
+
+func TestNewOutputImageStream(t *testing.T) {
+	t.Run("with scheduled imports", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:       "nodejs",
+			GitSourceRef:    "my-git-source",
+			OutputTags:      []string{"promoted"},
+			ScheduledImport: true,
+		})
+
+		//when
+		is := newOutputImageStream(cp)
+
+		//then
+		require.False(t, is.Spec.Tags[0].ImportPolicy.Scheduled, "tags of built images should not be imported")
+	})
+
+	t.Run("with scheduled imports of a tag referencing an external image", func(t *testing.T) {
+		//given
+		cp := newTestComponent(devconsoleapi.ComponentSpec{
+			BuildType:       "nodejs",
+			GitSourceRef:    "my-git-source",
+			ScheduledImport: true,
+		})
+		tags := []imagev1.TagReference{{
+			Name: "upstream",
+			From: &corev1.ObjectReference{Kind: "DockerImage", Name: "quay.io/example/api:latest"},
+		}}
+
+		//when
+		tags = scheduleImports(cp, tags)
+
+		//then
+		require.True(t, tags[0].ImportPolicy.Scheduled, "external image should be re-imported periodically")
+	})
+}
+
+func TestParseLimitRequestRatio(t *testing.T) {
+	t.Run("with a ratio", func(t *testing.T) {
+		//when
+		ratio, err := parseLimitRequestRatio("1.5")
+
+		//then
+		require.NoError(t, err)
+		require.Equal(t, 1.5, ratio)
+	})
+
+	t.Run("without a ratio", func(t *testing.T) {
+		//when
+		ratio, err := parseLimitRequestRatio("")
+
+		//then
+		require.NoError(t, err)
+		require.Zero(t, ratio, "limits and requests should not be derived")
+	})
+
+	t.Run("with a ratio lower than 1", func(t *testing.T) {
+		//when
+		_, err := parseLimitRequestRatio("0.5")
+
+		//then
+		require.EqualError(t, err, `LIMIT_REQUEST_RATIO "0.5" must be a number greater than or equal to 1`)
+	})
+}
+
+func TestValidateGitURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		valid bool
+	}{
+		{"https URL", "https://github.com/redhat-developer/devconsole-operator", true},
+		{"http URL", "http://git.example.com/team/app.git", true},
+		{"git URL", "git://git.example.com/team/app.git", true},
+		{"ssh URL", "ssh://git@git.example.com:2222/team/app.git", true},
+		{"scp-like URL", "git@github.com:redhat-developer/devconsole-operator.git", true},
+		{"empty URL", "", true},
+		{"URL without scheme", "github.com/redhat-developer/devconsole-operator", false},
+		{"URL with an unsupported scheme", "ftp://git.example.com/team/app.git", false},
+		{"URL without host", "https:///team/app.git", false},
+		{"malformed URL", "https://git.example.com/%zz", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//given
+			gitSource := newTestGitSource()
+			gitSource.Spec.URL = tc.url
+
+			//when
+			err := validateGitURL(gitSource)
+
+			//then
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, `invalid URL "`+tc.url+`" of GitSource my-git-source, expected an http(s), git or ssh URL`)
+			require.Equal(t, errorClassTerminal, classifyError(err), "an invalid URL should not be retried")
+		})
+	}
+}
+
+func TestMaintenanceWindowUntilOpen(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2019, 4, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		window   string
+		now      time.Time
+		expected time.Duration
+	}{
+		{"inside a daytime window", "09:00-17:00", at(12, 0), 0},
+		{"before a daytime window", "09:00-17:00", at(8, 30), 30 * time.Minute},
+		{"after a daytime window", "09:00-17:00", at(17, 0), 16 * time.Hour},
+		{"inside a window spanning midnight before it", "22:00-06:00", at(23, 0), 0},
+		{"inside a window spanning midnight after it", "22:00-06:00", at(5, 59), 0},
+		{"outside a window spanning midnight", "22:00-06:00", at(6, 0), 16 * time.Hour},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//given
+			window, err := parseMaintenanceWindow(tc.window)
+			require.NoError(t, err)
+
+			//when
+			wait := window.untilOpen(tc.now)
+
+			//then
+			require.Equal(t, tc.expected, wait)
+		})
+	}
+
+	t.Run("with invalid windows", func(t *testing.T) {
+		for _, window := range []string{"22:00", "22:00-25:00", "night", "06:00-06:00"} {
+			_, err := parseMaintenanceWindow(window)
+			require.Error(t, err, "window %q should be rejected", window)
+		}
+	})
+}
+
+func TestBuilderImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     devconsoleapi.ComponentSpec
+		expected string
+	}{
+		{"default version", devconsoleapi.ComponentSpec{BuildType: "nodejs"}, "nodeshift/centos7-s2i-nodejs:10.x"},
+		{"overridden version", devconsoleapi.ComponentSpec{BuildType: "nodejs", BuildTypeVersion: "12.x"}, "nodeshift/centos7-s2i-nodejs:12.x"},
+		{"overridden version of an image from a registry", devconsoleapi.ComponentSpec{BuildType: "dotnet", BuildTypeVersion: "2.1"}, "registry.access.redhat.com/dotnet/dotnet-22-rhel7:2.1"},
+		{"builder image ignoring the version", devconsoleapi.ComponentSpec{BuildType: "nodejs", BuildTypeVersion: "12.x", BuilderImage: "quay.io/example/nodejs-s2i:12"}, "quay.io/example/nodejs-s2i:12"},
+		{"unknown build type", devconsoleapi.ComponentSpec{BuildType: "cobol", BuildTypeVersion: "1"}, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//given
+			cp := newTestComponent(tc.spec)
+
+			//when
+			image := builderImage(cp)
+
+			//then
+			require.Equal(t, tc.expected, image)
+		})
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		port  int32
+		ports []int32
+		err   string
+	}{
+		{"default port", 0, nil, ""},
+		{"unprivileged port", 8080, nil, ""},
+		{"unprivileged ports", 0, []int32{8080, 9090}, ""},
+		{"privileged port", 80, nil, ""},
+		{"privileged port among ports", 8080, []int32{8080, 443}, ""},
+		{"port above the range", 70000, nil, "port 70000 is out of range [1-65535]"},
+		{"port above the range among ports", 8080, []int32{8080, 65536}, "port 65536 is out of range [1-65535]"},
+		{"unset port among ports", 8080, []int32{8080, 0}, "port 0 is out of range [1-65535]"},
+		{"duplicated port", 0, []int32{8080, 8080}, "port 8080 is exposed more than once"},
+		{"port repeated by ports", 8080, []int32{8080}, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//given
+			cp := newTestComponent(devconsoleapi.ComponentSpec{Port: tc.port, Ports: tc.ports})
+
+			//when
+			err := validatePorts(cp)
+
+			//then
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+			require.Equal(t, "InvalidPort", errorReason(err, ""))
+			require.Equal(t, errorClassTerminal, classifyError(err), "an invalid port should not be retried")
+		})
+	}
+}
+
+func TestDeploymentDrifted(t *testing.T) {
+	cp := newTestComponent(devconsoleapi.ComponentSpec{
+		Image: "quay.io/example/app:1.0",
+		Port:  8080,
+	})
+	ports := []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolTCP}}
+	desired := newDeploymentConfig(cp, nil, ports, 0)
+
+	t.Run("with the fields defaulted by the server", func(t *testing.T) {
+		//given
+		dc := desired.DeepCopy()
+		timeout := int64(600)
+		dc.Spec.Strategy.RecreateParams = &appsv1.RecreateDeploymentStrategyParams{TimeoutSeconds: &timeout}
+		dc.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
+		dc.Spec.Template.Spec.Containers[0].ReadinessProbe.PeriodSeconds = 10
+		dc.Spec.Template.Spec.Containers[0].ReadinessProbe.FailureThreshold = 3
+
+		//when
+		drifted := deploymentDrifted(cp, dc, desired)
+
+		//then
+		require.False(t, drifted, "fields defaulted by the server should be ignored")
+	})
+
+	t.Run("with a changed probe", func(t *testing.T) {
+		//given
+		dc := desired.DeepCopy()
+		dc.Spec.Template.Spec.Containers[0].LivenessProbe = nil
+
+		//when
+		drifted := deploymentDrifted(cp, dc, desired)
+
+		//then
+		require.True(t, drifted, "a removed probe should be restored")
+	})
+
+	t.Run("with changed resources", func(t *testing.T) {
+		//given
+		dc := desired.DeepCopy()
+		dc.Spec.Template.Spec.Containers[0].Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+
+		//when
+		drifted := deploymentDrifted(cp, dc, desired)
+
+		//then
+		require.True(t, drifted, "changed resources should be restored")
+	})
+
+	t.Run("with the image set by the image trigger", func(t *testing.T) {
+		//given
+		dc := desired.DeepCopy()
+		dc.Spec.Template.Spec.Containers[0].Image = "172.30.1.1:5000/myproject/app@sha256:abc"
+
+		//when
+		drifted := deploymentDrifted(cp, dc, desired)
+
+		//then
+		require.False(t, drifted, "deployed image should be left to the image trigger")
+	})
+
+	t.Run("with a removed preStop hook", func(t *testing.T) {
+		//given
+		withPreStop := newTestComponent(devconsoleapi.ComponentSpec{
+			Image:   "quay.io/example/app:1.0",
+			Port:    8080,
+			PreStop: &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"sh", "-c", "sleep 10"}}},
+		})
+		desired := newDeploymentConfig(withPreStop, nil, ports, 0)
+		dc := desired.DeepCopy()
+		dc.Spec.Template.Spec.Containers[0].Lifecycle = nil
+
+		//when
+		drifted := deploymentDrifted(withPreStop, dc, desired)
+
+		//then
+		require.True(t, drifted, "a removed preStop hook should be restored")
+	})
+
+	t.Run("with a changed selector", func(t *testing.T) {
+		//given
+		dc := desired.DeepCopy()
+		dc.Spec.Selector = map[string]string{"tier": "api"}
+
+		//when
+		drifted := deploymentDrifted(cp, dc, desired)
+
+		//then
+		require.True(t, drifted, "a changed selector should be restored")
+	})
+
+	t.Run("with replicas set by the autoscaler", func(t *testing.T) {
+		//given
+		autoscaled := newTestComponent(devconsoleapi.ComponentSpec{
+			Image:       "quay.io/example/app:1.0",
+			Port:        8080,
+			MinReplicas: 2,
+			MaxReplicas: 5,
+		})
+		desired := newDeploymentConfig(autoscaled, nil, ports, 0)
+		dc := desired.DeepCopy()
+		dc.Spec.Replicas = 4
+
+		//when
+		drifted := deploymentDrifted(autoscaled, dc, desired)
+
+		//then
+		require.False(t, drifted, "replicas set by the autoscaler should be ignored")
+	})
+}
+
+func TestServiceDrifted(t *testing.T) {
+	cp := newTestComponent(devconsoleapi.ComponentSpec{Port: 8080})
+	desired := newService(cp, 8080)
+
+	t.Run("with the fields defaulted by the server", func(t *testing.T) {
+		//given
+		svc := desired.DeepCopy()
+		svc.Spec.ClusterIP = "172.30.0.10"
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
+
+		//when
+		drifted := serviceDrifted(svc, desired)
+
+		//then
+		require.False(t, drifted, "fields defaulted by the server should be ignored")
+	})
+
+	t.Run("with a changed selector", func(t *testing.T) {
+		//given
+		svc := desired.DeepCopy()
+		svc.Spec.Selector["tier"] = "api"
+
+		//when
+		drifted := serviceDrifted(svc, desired)
+
+		//then
+		require.True(t, drifted, "a changed selector should be restored")
+	})
+
+	t.Run("with an added port", func(t *testing.T) {
+		//given
+		svc := desired.DeepCopy()
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Name: "debug", Port: 5858})
+
+		//when
+		drifted := serviceDrifted(svc, desired)
+
+		//then
+		require.True(t, drifted, "an added port should be removed")
+	})
+}
+
+func TestRouteDrifted(t *testing.T) {
+	cp := newTestComponent(devconsoleapi.ComponentSpec{Port: 8080, Exposed: true})
+	desired := newRoute(cp, 8080)
+
+	t.Run("with the host and the TLS configuration of the route", func(t *testing.T) {
+		//given
+		route := desired.DeepCopy()
+		route.Spec.Port.TargetPort = intstr.FromInt(8080)
+		route.Spec.Host = "app.example.com"
+		route.Spec.TLS = &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
+
+		//when
+		drifted := routeDrifted(route, desired)
+
+		//then
+		require.False(t, drifted, "host and TLS configuration should be kept")
+	})
+
+	t.Run("with a changed port", func(t *testing.T) {
+		//given
+		route := desired.DeepCopy()
+		route.Spec.Port.TargetPort = intstr.FromInt(9090)
+
+		//when
+		drifted := routeDrifted(route, desired)
+
+		//then
+		require.True(t, drifted, "a changed port should be restored")
+	})
+
+	t.Run("with a changed path", func(t *testing.T) {
+		//given
+		route := desired.DeepCopy()
+		route.Spec.Path = "/debug"
+
+		//when
+		drifted := routeDrifted(route, desired)
+
+		//then
+		require.True(t, drifted, "a changed path should be restored")
+	})
+}
@@ -0,0 +1,71 @@
+package component
+
+import (
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionReferencesResolved reports whether the Secrets and ConfigMaps referenced by the component exist.
+	ConditionReferencesResolved = "ReferencesResolved"
+	// ConditionImageImportFailed reports whether the builder ImageStream failed to import its image.
+	ConditionImageImportFailed = "ImageImportFailed"
+	// ConditionReconcileFailed reports whether the last reconcile of the component failed.
+	ConditionReconcileFailed = "ReconcileFailed"
+	// ConditionDegraded reports whether a DeploymentConfig of the component has been unavailable for longer than
+	// the grace period.
+	ConditionDegraded = "Degraded"
+	// ConditionApproved reports whether a component requiring an approval may be deployed.
+	ConditionApproved = "Approved"
+	// ConditionReady reports whether all the resources of the component are created and its image is built.
+	ConditionReady = "Ready"
+	// ConditionBuildConfigCreated reports whether the BuildConfig of the component has been created.
+	ConditionBuildConfigCreated = "BuildConfigCreated"
+	// ConditionDeploymentCreated reports whether the DeploymentConfig of the component has been created.
+	ConditionDeploymentCreated = "DeploymentCreated"
+	// ConditionRegistryReachable reports whether the registry the output image is pushed to could be reached.
+	ConditionRegistryReachable = "RegistryReachable"
+	// ConditionImageResolved reports whether the output ImageStreamTag deployed by the component points to an image.
+	ConditionImageResolved = "ImageResolved"
+	// ConditionDependenciesReady reports whether the Services the component waits for have ready endpoints.
+	ConditionDependenciesReady = "DependenciesReady"
+	// ConditionChangesDeferred reports whether the spec changes of the component wait for its maintenance window.
+	ConditionChangesDeferred = "ChangesDeferred"
+)
+
+// setCondition adds or updates the condition of the given type in the component's status.
+// The transition time is only bumped when the condition status actually changes.
+func setCondition(cp *devconsoleapi.Component, conditionType string, status corev1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	for i := range cp.Status.Conditions {
+		condition := &cp.Status.Conditions[i]
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status != status {
+			condition.LastTransitionTime = now
+		}
+		condition.Status = status
+		condition.Reason = reason
+		condition.Message = message
+		return
+	}
+	cp.Status.Conditions = append(cp.Status.Conditions, devconsoleapi.ComponentCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// getCondition returns the condition of the given type, or nil if it has not been set yet.
+func getCondition(cp *devconsoleapi.Component, conditionType string) *devconsoleapi.ComponentCondition {
+	for i := range cp.Status.Conditions {
+		if cp.Status.Conditions[i].Type == conditionType {
+			return &cp.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+)
+
+// dockerfileDetector tells whether the git repository of a component has a Dockerfile in its context directory.
+type dockerfileDetector interface {
+	HasDockerfile(ctx context.Context, gitSource *devconsoleapi.GitSource, contextDir string) (bool, error)
+}
+
+// rawFileDockerfileDetector looks for the Dockerfile through the raw file endpoint of the public GitHub and GitLab
+// repositories. Repositories hosted elsewhere are reported without Dockerfile, so that they are built with S2I.
+type rawFileDockerfileDetector struct {
+	client *http.Client
+}
+
+func (d rawFileDockerfileDetector) HasDockerfile(ctx context.Context, gitSource *devconsoleapi.GitSource, contextDir string) (bool, error) {
+	rawURL := rawDockerfileURL(gitSource, contextDir)
+	if rawURL == "" {
+		return false, nil
+	}
+	req, err := http.NewRequest(http.MethodHead, rawURL, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := d.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected status %s looking for %s", resp.Status, rawURL)
+}
+
+// rawDockerfileURL returns the URL of the raw Dockerfile of the repository on GitHub or GitLab, or an empty string
+// for the repositories hosted elsewhere.
+func rawDockerfileURL(gitSource *devconsoleapi.GitSource, contextDir string) string {
+	repo, err := url.Parse(gitSource.Spec.URL)
+	if err != nil {
+		return ""
+	}
+	repoPath := strings.TrimSuffix(strings.Trim(repo.Path, "/"), ".git")
+	ref := gitSource.Spec.Ref
+	if ref == "" {
+		ref = "master"
+	}
+	file := path.Join(contextDir, "Dockerfile")
+	switch repo.Host {
+	case "github.com":
+		return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s", repoPath, ref, file)
+	case "gitlab.com":
+		return fmt.Sprintf("https://gitlab.com/%s/raw/%s/%s", repoPath, ref, file)
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package component
+
+import (
+	"fmt"
+
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// reportDrift records in the status of a component in drift detection only mode that the given part of obj
+// drifted from its desired state, instead of correcting it. It tells whether the drift was reported, in which case
+// obj must be left untouched.
+func (r *ReconcileComponent) reportDrift(cp *devconsoleapi.Component, obj runtime.Object, what string) bool {
+	if !cp.Spec.DriftDetectionOnly {
+		return false
+	}
+	kind := "Unknown"
+	if gvk, err := apiutil.GVKForObject(obj, r.scheme); err == nil {
+		kind = gvk.Kind
+	}
+	name := ""
+	if accessor, err := meta.Accessor(obj); err == nil {
+		name = accessor.GetName()
+	}
+	requestLogger(cp).Info("** Drift detected, leaving resource untouched **", "Kind", kind, "Name", name, "Drift", what)
+	cp.Status.Drift = append(cp.Status.Drift, fmt.Sprintf("%s %s: %s", kind, name, what))
+	return true
+}
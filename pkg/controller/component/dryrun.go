@@ -0,0 +1,124 @@
+package component
+
+import (
+	imagev1 "github.com/openshift/api/image/v1"
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// RenderManifests computes the resources the component would generate and stores them as YAML in its status,
+// without creating any of them. Only read-only lookups are made: the builder image is not imported, so the exposed
+// port of a builder ImageStream to be created falls back to the default one.
+func (r *ReconcileComponent) RenderManifests(cp *devconsoleapi.Component) error {
+	var objs []runtime.Object
+	var outputIS, builderIS *imagev1.ImageStream
+	if err := validatePorts(cp); err != nil {
+		return err
+	}
+	if prebuilt(cp) {
+		if err := validatePrebuiltImage(cp); err != nil {
+			return err
+		}
+	} else {
+		gitSource, err := r.GetGitSource(cp)
+		if err != nil {
+			return err
+		}
+		err = validateGitURL(gitSource)
+		if err != nil {
+			return err
+		}
+		err = r.ValidateBuildType(cp)
+		if err != nil {
+			return err
+		}
+		err = validateBuildStrategy(cp)
+		if err != nil {
+			return err
+		}
+		err = validateBuildEnv(cp)
+		if err != nil {
+			return err
+		}
+		err = r.ResolveBuildStrategy(cp, gitSource)
+		if err != nil {
+			return err
+		}
+		err = validateImageStreamUpdatePolicy(cp)
+		if err != nil {
+			return err
+		}
+		builder, createBuilder, err := r.resolveBuilderImageStream(cp)
+		if err != nil {
+			return err
+		}
+		if !createBuilder {
+			builderIS, err = r.CreateBuilderImageStream(cp, builder, false)
+			if err != nil {
+				return err
+			}
+		}
+		secret, _ := r.GetSourceSecret(cp, gitSource)
+		outputIS = newOutputImageStream(cp)
+		objs = append(objs, outputIS, newBuildConfig(cp, builder, gitSource, secret))
+	}
+	err := validateEnv(cp)
+	if err != nil {
+		return err
+	}
+	err = validateContainerResources(cp)
+	if err != nil {
+		return err
+	}
+	err = validateVolumes(cp)
+	if err != nil {
+		return err
+	}
+	err = validateReplicas(cp)
+	if err != nil {
+		return err
+	}
+	err = validateDeployStrategy(cp)
+	if err != nil {
+		return err
+	}
+	err = validateSelector(cp)
+	if err != nil {
+		return err
+	}
+	ports, err := r.GetExposedPorts(cp, "latest", builderIS)
+	if err != nil {
+		return err
+	}
+	svc := newService(cp, ports[0].ContainerPort)
+	for _, volume := range cp.Spec.Volumes {
+		objs = append(objs, newPersistentVolumeClaim(cp, volume))
+	}
+	objs = append(objs, newDeploymentConfig(cp, outputIS, ports, r.limitRequestRatio), svc)
+	if autoscalingEnabled(cp) {
+		objs = append(objs, newHorizontalPodAutoscaler(cp))
+	}
+	if featureEnabled(cp, featureRoute, cp.Spec.Exposed) {
+		objs = append(objs, newRoute(cp, ports[0].ContainerPort))
+	}
+
+	manifests := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		// the generated objects do not carry their kind, which the manifests need to be applied as they are
+		gvk, err := apiutil.GVKForObject(obj, r.scheme)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		manifest, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, string(manifest))
+	}
+	requestLogger(cp).Info("** Rendered the resources of the component without creating them **")
+	cp.Status.RenderedManifests = manifests
+	return r.UpdateComponentStatus(cp)
+}
@@ -0,0 +1,114 @@
+package component
+
+import (
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// errorClass tells how reconcile should react to an error.
+type errorClass int
+
+const (
+	// errorClassRetriable errors are transient, the request is requeued with backoff.
+	errorClassRetriable errorClass = iota
+	// errorClassConflict errors come from a stale read, the request is requeued immediately.
+	errorClassConflict
+	// errorClassTerminal errors can only be fixed by changing the component, retrying is pointless.
+	errorClassTerminal
+)
+
+// terminalError wraps an error which cannot be solved by reconciling again, typically an invalid spec.
+type terminalError struct {
+	err error
+}
+
+func (e *terminalError) Error() string {
+	return e.err.Error()
+}
+
+// newTerminalError marks the given error as terminal.
+func newTerminalError(err error) error {
+	return &terminalError{err: err}
+}
+
+// reasonError gives a reconcile error a reason more specific than its class, reported on the component.
+type reasonError struct {
+	reason string
+	err    error
+}
+
+func (e *reasonError) Error() string {
+	return e.err.Error()
+}
+
+// withReason sets the reason reported on the component for the given error.
+func withReason(reason string, err error) error {
+	return &reasonError{reason: reason, err: err}
+}
+
+// errorReason returns the reason set on the given error, or defaultReason if it has none.
+func errorReason(err error, defaultReason string) string {
+	if e, ok := err.(*reasonError); ok {
+		return e.reason
+	}
+	return defaultReason
+}
+
+// classifyError returns the class of the given reconcile error.
+func classifyError(err error) errorClass {
+	if e, ok := err.(*reasonError); ok {
+		return classifyError(e.err)
+	}
+	if _, ok := err.(*terminalError); ok {
+		return errorClassTerminal
+	}
+	switch {
+	case errors.IsConflict(err):
+		return errorClassConflict
+	case errors.IsInvalid(err), errors.IsBadRequest(err):
+		return errorClassTerminal
+	}
+	return errorClassRetriable
+}
+
+// handleError records the error returned by a reconcile step on the component and decides whether the request
+// is retried, based on the class of the error.
+func (r *ReconcileComponent) handleError(cp *devconsoleapi.Component, err error) (reconcile.Result, error) {
+	reqLogger := requestLogger(cp)
+	switch classifyError(err) {
+	case errorClassConflict:
+		reqLogger.Info("** Conflict while reconciling component, requeuing **", "Error", err.Error())
+		return reconcile.Result{Requeue: true}, nil
+	case errorClassTerminal:
+		reqLogger.Error(err, "** Component cannot be reconciled until its spec is fixed **")
+		r.recordReconcileError(cp, errorReason(err, "TerminalError"), err)
+		return reconcile.Result{}, nil
+	default:
+		r.recordReconcileError(cp, errorReason(err, "RetriableError"), err)
+		return reconcile.Result{}, err
+	}
+}
+
+// requeueOnConflict returns the given result once the status of the given component has been written, as told by
+// the given error. A write conflicting with a concurrent reconcile requeues the request immediately rather than
+// failing.
+func requeueOnConflict(cp *devconsoleapi.Component, result reconcile.Result, err error) (reconcile.Result, error) {
+	if err != nil && classifyError(err) == errorClassConflict {
+		requestLogger(cp).Info("** Conflict while updating component, requeuing **", "Error", err.Error())
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return result, err
+}
+
+// recordReconcileError sets the ReconcileFailed condition on the component, marks it as not ready and records a
+// warning event. Failing to persist the conditions is only logged as the original error matters more.
+func (r *ReconcileComponent) recordReconcileError(cp *devconsoleapi.Component, reason string, err error) {
+	r.event(cp, corev1.EventTypeWarning, reason, err.Error())
+	setCondition(cp, ConditionReconcileFailed, corev1.ConditionTrue, reason, err.Error())
+	setCondition(cp, ConditionReady, corev1.ConditionFalse, reason, err.Error())
+	if updateErr := r.UpdateComponentStatus(cp); updateErr != nil {
+		requestLogger(cp).Error(updateErr, "** failed to record reconcile error on component **")
+	}
+}
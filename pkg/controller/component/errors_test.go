@@ -0,0 +1,74 @@
+package component
+
+import (
+	e "errors"
+	"testing"
+
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestClassifyError(t *testing.T) {
+	resource := schema.GroupResource{Group: "apps.openshift.io", Resource: "deploymentconfigs"}
+	kind := schema.GroupKind{Group: "apps.openshift.io", Kind: "DeploymentConfig"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected errorClass
+	}{
+		{"terminal error", newTerminalError(e.New("invalid spec")), errorClassTerminal},
+		{"conflict", errors.NewConflict(resource, Name, e.New("object has been modified")), errorClassConflict},
+		{"invalid", errors.NewInvalid(kind, Name, field.ErrorList{field.Required(field.NewPath("spec"), "")}), errorClassTerminal},
+		{"bad request", errors.NewBadRequest("bad request"), errorClassTerminal},
+		{"not found", errors.NewNotFound(resource, Name), errorClassRetriable},
+		{"server timeout", errors.NewServerTimeout(resource, "get", 1), errorClassRetriable},
+		{"generic error", e.New("connection refused"), errorClassRetriable},
+		{"terminal error with a reason", withReason("UnsupportedBuildType", newTerminalError(e.New("invalid spec"))), errorClassTerminal},
+		{"generic error with a reason", withReason("MissingBuildType", e.New("no build type")), errorClassRetriable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//when
+			class := classifyError(tc.err)
+
+			//then
+			require.Equal(t, tc.expected, class, "unexpected class for %v", tc.err)
+		})
+	}
+}
+
+func TestRequeueOnConflict(t *testing.T) {
+	resource := schema.GroupResource{Group: "devconsole.openshift.io", Resource: "components"}
+	waiting := reconcile.Result{RequeueAfter: approvalRequeueDelay}
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedResult reconcile.Result
+		expectedErr    bool
+	}{
+		{"written status", nil, waiting, false},
+		{"conflict", errors.NewConflict(resource, Name, e.New("object has been modified")), reconcile.Result{Requeue: true}, false},
+		{"generic error", e.New("connection refused"), waiting, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//when
+			result, err := requeueOnConflict(newTestComponent(devconsoleapi.ComponentSpec{}), waiting, tc.err)
+
+			//then
+			require.Equal(t, tc.expectedResult, result)
+			if tc.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package component
+
+import (
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+)
+
+// event records an event of the given type on the component, when the reconciler has an event recorder.
+func (r *ReconcileComponent) event(cp *devconsoleapi.Component, eventType, reason, message string) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Event(cp, eventType, reason, message)
+}
+
+// lifecycleEvent records an event about a build or a rollout of the component, also on the object the component
+// references as involved in its events, so that tooling aggregating the events of a parent object sees them.
+func (r *ReconcileComponent) lifecycleEvent(cp *devconsoleapi.Component, eventType, reason, message string) {
+	r.event(cp, eventType, reason, message)
+	if r.recorder == nil || cp.Spec.InvolvedObject == nil {
+		return
+	}
+	involved := cp.Spec.InvolvedObject.DeepCopy()
+	if involved.Namespace == "" {
+		involved.Namespace = cp.Namespace
+	}
+	r.recorder.Event(involved, eventType, reason, message)
+}
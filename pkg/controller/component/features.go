@@ -0,0 +1,50 @@
+package component
+
+import (
+	"strconv"
+	"strings"
+
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+)
+
+// featureAnnotationPrefix is the prefix of the Component annotations which enable or disable a generator,
+// e.g. devconsole.io/feature-route: "false".
+const featureAnnotationPrefix = "devconsole.io/feature-"
+
+// orphanAnnotation lists the kinds of generated resources, e.g. "route,service", which the user manages on their
+// own: once deleted, the operator does not recreate them.
+const orphanAnnotation = "devconsole.io/orphan"
+
+const (
+	// featureRoute gates the generation of the Route exposing the component.
+	featureRoute = "route"
+	// featureRegistryCheck gates the check that the output registry is reachable before the BuildConfig is created.
+	featureRegistryCheck = "registry-check"
+	// featureLabelSync gates the reconcile of the labels of the already created resources.
+	featureLabelSync = "label-sync"
+)
+
+// featureEnabled tells whether the given feature is enabled for the component. When the component has no
+// feature-gate annotation for it, or when the annotation is not a boolean, defaultValue is returned.
+func featureEnabled(cp *devconsoleapi.Component, feature string, defaultValue bool) bool {
+	value, ok := cp.Annotations[featureAnnotationPrefix+feature]
+	if !ok {
+		return defaultValue
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		requestLogger(cp).Info("** Ignoring invalid feature gate annotation **", "Feature", feature, "Value", value)
+		return defaultValue
+	}
+	return enabled
+}
+
+// orphaned tells whether the user asked the operator not to recreate the component's resource of the given kind.
+func orphaned(cp *devconsoleapi.Component, kind string) bool {
+	for _, orphan := range strings.Split(cp.Annotations[orphanAnnotation], ",") {
+		if strings.EqualFold(strings.TrimSpace(orphan), kind) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package component
+
+import (
+	"context"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	buildv1 "github.com/openshift/api/build/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	"github.com/redhat-developer/devconsole-operator/pkg/audit"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// componentFinalizer holds the deletion of a component until the operator cleaned up its resources, including
+// the ones which are not garbage collected through owner references.
+const componentFinalizer = "devconsole.io/cleanup"
+
+// hasFinalizer tells whether the component carries the finalizer of the operator.
+func hasFinalizer(cp *devconsoleapi.Component) bool {
+	for _, finalizer := range cp.Finalizers {
+		if finalizer == componentFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFinalizer registers the finalizer of the operator on the component.
+func (r *ReconcileComponent) AddFinalizer(cp *devconsoleapi.Component) error {
+	requestLogger(cp).Info("** Adding the finalizer of the component **")
+	cp.Finalizers = append(cp.Finalizers, componentFinalizer)
+	return r.UpdateComponent(cp)
+}
+
+// Finalize deletes the resources generated for a component being deleted, then removes the finalizer of the
+// operator so that the deletion completes. The builder ImageStream is kept as it is either shared by the components
+// of the namespace with the same build type or garbage collected with the component, and so are the resources
+// orphaned by the user.
+func (r *ReconcileComponent) Finalize(cp *devconsoleapi.Component) error {
+	if !hasFinalizer(cp) {
+		return nil
+	}
+	children := map[string]runtime.Object{
+		"route":                   &routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: cp.Namespace}},
+		"service":                 &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: cp.Namespace}},
+		"horizontalpodautoscaler": &autoscalingv1.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: cp.Namespace}},
+		"deploymentconfig":        &appsv1.DeploymentConfig{ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: cp.Namespace}},
+		"buildconfig":             &buildv1.BuildConfig{ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: cp.Namespace}},
+		"imagestream":             &imagev1.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: cp.Name, Namespace: outputNamespace(cp)}},
+	}
+	for _, kind := range []string{"route", "service", "horizontalpodautoscaler", "deploymentconfig", "buildconfig", "imagestream"} {
+		if orphaned(cp, kind) {
+			continue
+		}
+		if err := r.deleteChild(cp, children[kind]); err != nil {
+			return err
+		}
+	}
+	finalizers := cp.Finalizers[:0]
+	for _, finalizer := range cp.Finalizers {
+		if finalizer != componentFinalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	cp.Finalizers = finalizers
+	requestLogger(cp).Info("** Removing the finalizer of the component **")
+	return r.UpdateComponent(cp)
+}
+
+// deleteChild deletes a resource generated for the component, which may already be gone.
+func (r *ReconcileComponent) deleteChild(cp *devconsoleapi.Component, obj runtime.Object) error {
+	reqLogger := requestLogger(cp)
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	err = r.client.Delete(context.TODO(), obj)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		reqLogger.Error(err, "** Deleting a resource of the component fails **", "Namespace", accessor.GetNamespace(), "Name", accessor.GetName())
+		return err
+	}
+	reqLogger.Info("👻👻 Deleted a resource of the component 👻👻", "Namespace", accessor.GetNamespace(), "Name", accessor.GetName())
+	r.audit(cp, audit.ActionDelete, obj)
+	return nil
+}
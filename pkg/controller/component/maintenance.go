@@ -0,0 +1,99 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maintenanceWindow is the daily time range, in UTC, during which the spec changes of a component may be applied.
+// A window ending before it starts spans midnight, e.g. 22:00-06:00.
+type maintenanceWindow struct {
+	start, end time.Duration
+}
+
+// parseMaintenanceWindow parses a window written as HH:MM-HH:MM.
+func parseMaintenanceWindow(value string) (maintenanceWindow, error) {
+	bounds := strings.Split(value, "-")
+	if len(bounds) != 2 {
+		return maintenanceWindow{}, fmt.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM", value)
+	}
+	var offsets [2]time.Duration
+	for i, bound := range bounds {
+		t, err := time.Parse("15:04", strings.TrimSpace(bound))
+		if err != nil {
+			return maintenanceWindow{}, fmt.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM", value)
+		}
+		offsets[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if offsets[0] == offsets[1] {
+		return maintenanceWindow{}, fmt.Errorf("invalid maintenance window %q, it starts when it ends", value)
+	}
+	return maintenanceWindow{start: offsets[0], end: offsets[1]}, nil
+}
+
+// untilOpen returns how long to wait from now for the window to open, zero when it is open.
+func (w maintenanceWindow) untilOpen(now time.Time) time.Duration {
+	now = now.UTC()
+	offset := now.Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if w.start < w.end && offset >= w.start && offset < w.end {
+		return 0
+	}
+	if w.start > w.end && (offset >= w.start || offset < w.end) {
+		return 0
+	}
+	wait := w.start - offset
+	if wait < 0 {
+		wait += 24 * time.Hour
+	}
+	return wait
+}
+
+// validateMaintenanceWindow checks that the maintenance window of the component, if any, can be parsed.
+func validateMaintenanceWindow(cp *devconsoleapi.Component) error {
+	if cp.Spec.MaintenanceWindow == "" {
+		return nil
+	}
+	if _, err := parseMaintenanceWindow(cp.Spec.MaintenanceWindow); err != nil {
+		return withReason("InvalidMaintenanceWindow", newTerminalError(err))
+	}
+	return nil
+}
+
+// CheckMaintenanceWindow tells how long the spec changes of the component are deferred for, zero when they may be
+// applied. Only the changes of a component which has already been deployed wait for its maintenance window, the
+// first deployment does not.
+func (r *ReconcileComponent) CheckMaintenanceWindow(cp *devconsoleapi.Component) (time.Duration, error) {
+	if err := validateMaintenanceWindow(cp); err != nil {
+		return 0, err
+	}
+	var wait time.Duration
+	if cp.Spec.MaintenanceWindow != "" && cp.Status.ObservedGeneration != 0 && cp.Status.ObservedGeneration != cp.Generation {
+		window, _ := parseMaintenanceWindow(cp.Spec.MaintenanceWindow)
+		wait = window.untilOpen(r.clock())
+	}
+	if wait > 0 {
+		message := fmt.Sprintf("changes are deferred until the maintenance window %s UTC opens", cp.Spec.MaintenanceWindow)
+		requestLogger(cp).Info("** " + message + " **")
+		if condition := getCondition(cp, ConditionChangesDeferred); condition != nil && condition.Status == corev1.ConditionTrue && condition.Message == message {
+			return wait, nil
+		}
+		setCondition(cp, ConditionChangesDeferred, corev1.ConditionTrue, "OutsideMaintenanceWindow", message)
+		return wait, r.UpdateComponentStatus(cp)
+	}
+	if condition := getCondition(cp, ConditionChangesDeferred); condition != nil && condition.Status != corev1.ConditionFalse {
+		setCondition(cp, ConditionChangesDeferred, corev1.ConditionFalse, "InsideMaintenanceWindow", "")
+	}
+	return 0, nil
+}
+
+// clock returns the current time, which tests may set.
+func (r *ReconcileComponent) clock() time.Time {
+	if r.now == nil {
+		return time.Now()
+	}
+	return r.now()
+}
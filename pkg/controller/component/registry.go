@@ -0,0 +1,40 @@
+package component
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	imagev1 "github.com/openshift/api/image/v1"
+)
+
+// registryChecker tells whether an image registry is reachable.
+type registryChecker interface {
+	Check(ctx context.Context, host string) error
+}
+
+// dialRegistryChecker checks that a TCP connection can be opened to the registry. Nothing is sent, so neither
+// credentials nor certificates are needed.
+type dialRegistryChecker struct{}
+
+func (dialRegistryChecker) Check(ctx context.Context, host string) error {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "443")
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// registryHost returns the host of the registry the ImageStream is pushed to, or an empty string until OpenShift
+// sets its repository.
+func registryHost(is *imagev1.ImageStream) string {
+	repository := is.Status.DockerImageRepository
+	if i := strings.Index(repository, "/"); i > 0 {
+		return repository[:i]
+	}
+	return ""
+}
@@ -1,11 +1,24 @@
 package component
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	appsv1 "github.com/openshift/api/apps/v1"
+	buildv1 "github.com/openshift/api/build/v1"
 	"github.com/openshift/api/image/docker10"
 	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	devconsoleapi "github.com/redhat-developer/devconsole-api/pkg/apis/devconsole/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -76,3 +89,571 @@ func imageWithMetadata(image *imagev1.Image) error {
 
 	return nil
 }
+
+// getImageImportFailure returns a message describing why the ImageStream failed to import one of its tags,
+// or an empty string if all imports succeeded so far.
+func getImageImportFailure(is *imagev1.ImageStream) string {
+	for _, tag := range is.Status.Tags {
+		for _, condition := range tag.Conditions {
+			if condition.Type == imagev1.ImportSuccess && condition.Status == corev1.ConditionFalse {
+				return fmt.Sprintf("unable to import tag %s of ImageStream %s/%s: %s", tag.Tag, is.Namespace, is.Name, condition.Message)
+			}
+		}
+	}
+	return ""
+}
+
+// tagResolved tells whether the given tag of the ImageStream points to an image.
+func tagResolved(is *imagev1.ImageStream, tag string) bool {
+	return tagImage(is, tag) != ""
+}
+
+// tagImage returns the digest of the image the given tag of the ImageStream currently points to, the first item of
+// its history, or an empty string while the tag does not resolve to an image.
+func tagImage(is *imagev1.ImageStream, tag string) string {
+	for _, history := range is.Status.Tags {
+		if history.Tag == tag && len(history.Items) > 0 {
+			return history.Items[0].Image
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of the given map in a stable order, so that generated resources do not change
+// between reconciles.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// supportedBuildTypes returns the build types whose builder image is known without looking up the openshift namespace.
+func supportedBuildTypes() []string {
+	types := make([]string, 0, len(buildTypeImages))
+	for buildType := range buildTypeImages {
+		types = append(types, buildType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// buildTriggerTypes returns the types of the given build triggers, ignoring the state OpenShift records in them.
+func buildTriggerTypes(triggers []buildv1.BuildTriggerPolicy) []buildv1.BuildTriggerType {
+	var types []buildv1.BuildTriggerType
+	for _, trigger := range triggers {
+		types = append(types, trigger.Type)
+	}
+	return types
+}
+
+// mergeLabels sets the desired labels on the existing ones, keeping the labels which are not managed by the
+// operator. It tells whether any label was added or changed.
+func mergeLabels(existing, desired map[string]string) (map[string]string, bool) {
+	changed := false
+	for key, value := range desired {
+		if current, ok := existing[key]; ok && current == value {
+			continue
+		}
+		if existing == nil {
+			existing = map[string]string{}
+		}
+		existing[key] = value
+		changed = true
+	}
+	return existing, changed
+}
+
+// validateEnv checks that the environment variables of the component have unique names, as the last one would
+// otherwise silently win.
+func validateEnv(cp *devconsoleapi.Component) error {
+	return validateEnvNames("environment", cp.Spec.Env)
+}
+
+// validateBuildEnv checks that the environment variables of the build of the component have unique names.
+func validateBuildEnv(cp *devconsoleapi.Component) error {
+	return validateEnvNames("build environment", cp.Spec.BuildEnv)
+}
+
+func validateEnvNames(kind string, envs []corev1.EnvVar) error {
+	names := map[string]bool{}
+	for _, env := range envs {
+		if names[env.Name] {
+			err := fmt.Errorf("%s variable %s is set more than once", kind, env.Name)
+			return withReason("DuplicateEnv", newTerminalError(err))
+		}
+		names[env.Name] = true
+	}
+	return nil
+}
+
+// pinnedImage returns the image deployed by a component pinning its deployed image, or an empty string while it
+// tracks the latest tag of its output ImageStream.
+func pinnedImage(cp *devconsoleapi.Component) string {
+	if !cp.Spec.PinDeployedImage {
+		return ""
+	}
+	return cp.Status.PinnedImage
+}
+
+// hasImageChangeTrigger tells whether the DeploymentConfig rolls out the new images of an ImageStreamTag.
+func hasImageChangeTrigger(dc *appsv1.DeploymentConfig) bool {
+	return imageChangeTag(dc) != ""
+}
+
+// imageChangeTag returns the ImageStreamTag whose new images the DeploymentConfig rolls out, or an empty string.
+func imageChangeTag(dc *appsv1.DeploymentConfig) string {
+	for _, trigger := range dc.Spec.Triggers {
+		if trigger.Type == appsv1.DeploymentTriggerOnImageChange && trigger.ImageChangeParams != nil {
+			return trigger.ImageChangeParams.From.Name
+		}
+	}
+	return ""
+}
+
+// endpointsReady tells whether the Endpoints have at least one ready address.
+func endpointsReady(endpoints *corev1.Endpoints) bool {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// buildInFlight tells whether the build is neither finished nor already being cancelled.
+func buildInFlight(build *buildv1.Build) bool {
+	if build.Status.Cancelled {
+		return false
+	}
+	switch build.Status.Phase {
+	case buildv1.BuildPhaseNew, buildv1.BuildPhasePending, buildv1.BuildPhaseRunning:
+		return true
+	}
+	return false
+}
+
+// rolloutComplete tells whether the latest rollout of the DeploymentConfig made its new ReplicationController
+// available.
+func rolloutComplete(dc *appsv1.DeploymentConfig) bool {
+	if dc.Status.ObservedGeneration < dc.Generation {
+		return false
+	}
+	for _, condition := range dc.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Status == corev1.ConditionTrue && condition.Reason == string(appsv1.NewReplicationControllerAvailableReason)
+		}
+	}
+	return false
+}
+
+// serviceDrifted tells whether the spec of an existing Service no longer matches the desired one. The fields
+// defaulted by the server, e.g. its cluster IP, are ignored.
+func serviceDrifted(svc, desired *corev1.Service) bool {
+	return len(svc.Spec.Ports) != len(desired.Spec.Ports) ||
+		!equality.Semantic.DeepEqual(svc.Spec.Selector, desired.Spec.Selector) ||
+		!equality.Semantic.DeepDerivative(desired.Spec, svc.Spec)
+}
+
+// routeDrifted tells whether the spec of an existing Route no longer matches the desired one. Its host and its TLS
+// configuration are ignored unless desired, as are the fields defaulted by the server.
+func routeDrifted(route, desired *routev1.Route) bool {
+	expected := desired.Spec.DeepCopy()
+	if expected.Port != nil {
+		// only the number of the target port is persisted, not its name
+		expected.Port.TargetPort = intstr.Parse(expected.Port.TargetPort.String())
+	}
+	return (route.Spec.Port == nil) != (expected.Port == nil) ||
+		route.Spec.Path != expected.Path ||
+		len(route.Spec.AlternateBackends) != len(expected.AlternateBackends) ||
+		!equality.Semantic.DeepDerivative(*expected, route.Spec)
+}
+
+// deploymentDrifted tells whether the replicas, the strategy, the selector or the pod template of an existing
+// DeploymentConfig no longer match the desired ones. The replicas of an autoscaled component belong to the
+// autoscaler and are ignored, as are the fields defaulted by the server.
+func deploymentDrifted(cp *devconsoleapi.Component, dc, desired *appsv1.DeploymentConfig) bool {
+	if !autoscalingEnabled(cp) && dc.Spec.Replicas != desired.Spec.Replicas {
+		return true
+	}
+	if dc.Spec.Strategy.Type != desired.Spec.Strategy.Type || !equality.Semantic.DeepDerivative(desired.Spec.Strategy, dc.Spec.Strategy) {
+		return true
+	}
+	if !equality.Semantic.DeepEqual(dc.Spec.Selector, desired.Spec.Selector) {
+		return true
+	}
+	return templateDrifted(dc.Spec.Template, expectedTemplate(dc, desired))
+}
+
+// expectedTemplate returns the desired pod template of an existing DeploymentConfig, with the images its containers
+// currently deploy: these are reconciled by ReconcileImage, or set by the ImageChange trigger.
+func expectedTemplate(dc, desired *appsv1.DeploymentConfig) *corev1.PodTemplateSpec {
+	expected := desired.Spec.Template.DeepCopy()
+	for i := range expected.Spec.Containers {
+		container := &expected.Spec.Containers[i]
+		for _, deployed := range dc.Spec.Template.Spec.Containers {
+			if deployed.Name == container.Name {
+				container.Image = deployed.Image
+			}
+		}
+	}
+	return expected
+}
+
+// templateDrifted tells whether an existing pod template no longer matches the expected one. The fields left unset
+// by the expected template are only compared when the server does not default them.
+func templateDrifted(template, expected *corev1.PodTemplateSpec) bool {
+	podSpec, expectedSpec := template.Spec, expected.Spec
+	if !equality.Semantic.DeepEqual(template.Labels, expected.Labels) ||
+		len(podSpec.Containers) != len(expectedSpec.Containers) ||
+		len(podSpec.Volumes) != len(expectedSpec.Volumes) ||
+		!equality.Semantic.DeepEqual(podSpec.ImagePullSecrets, expectedSpec.ImagePullSecrets) ||
+		(podSpec.Affinity == nil) != (expectedSpec.Affinity == nil) ||
+		(podSpec.AutomountServiceAccountToken == nil) != (expectedSpec.AutomountServiceAccountToken == nil) {
+		return true
+	}
+	// the server defaults a missing security context to an empty one
+	securityContext, expectedSecurityContext := podSpec.SecurityContext, expectedSpec.SecurityContext
+	if securityContext == nil {
+		securityContext = &corev1.PodSecurityContext{}
+	}
+	if expectedSecurityContext == nil {
+		expectedSecurityContext = &corev1.PodSecurityContext{}
+	}
+	if !equality.Semantic.DeepEqual(securityContext, expectedSecurityContext) {
+		return true
+	}
+	expected = expected.DeepCopy()
+	for i := range expected.Spec.Containers {
+		container, expectedContainer := podSpec.Containers[i], &expected.Spec.Containers[i]
+		if container.Name != expectedContainer.Name ||
+			len(container.Ports) != len(expectedContainer.Ports) ||
+			len(container.Env) != len(expectedContainer.Env) ||
+			len(container.EnvFrom) != len(expectedContainer.EnvFrom) ||
+			len(container.VolumeMounts) != len(expectedContainer.VolumeMounts) ||
+			!equality.Semantic.DeepEqual(container.Resources, expectedContainer.Resources) ||
+			(container.Lifecycle == nil) != (expectedContainer.Lifecycle == nil) ||
+			(container.ReadinessProbe == nil) != (expectedContainer.ReadinessProbe == nil) ||
+			(container.LivenessProbe == nil) != (expectedContainer.LivenessProbe == nil) {
+			return true
+		}
+		expectedContainer.ReadinessProbe = defaultedProbe(expectedContainer.ReadinessProbe, container.ReadinessProbe)
+		expectedContainer.LivenessProbe = defaultedProbe(expectedContainer.LivenessProbe, container.LivenessProbe)
+	}
+	return !equality.Semantic.DeepDerivative(expected, template)
+}
+
+// defaultedProbe returns the expected probe with the timeout, the period and the thresholds it leaves unset, which
+// the server defaults, taken from the existing probe.
+func defaultedProbe(expected, probe *corev1.Probe) *corev1.Probe {
+	if expected == nil || probe == nil {
+		return expected
+	}
+	defaulted := *expected
+	if defaulted.TimeoutSeconds == 0 {
+		defaulted.TimeoutSeconds = probe.TimeoutSeconds
+	}
+	if defaulted.PeriodSeconds == 0 {
+		defaulted.PeriodSeconds = probe.PeriodSeconds
+	}
+	if defaulted.SuccessThreshold == 0 {
+		defaulted.SuccessThreshold = probe.SuccessThreshold
+	}
+	if defaulted.FailureThreshold == 0 {
+		defaulted.FailureThreshold = probe.FailureThreshold
+	}
+	return &defaulted
+}
+
+// newContainerResources parses the resource limits and requests of the component's container. The missing ones are
+// derived from the others with the given ratio between the limits and the requests, unless it is 0.
+func newContainerResources(cp *devconsoleapi.Component, limitRequestRatio float64) (corev1.ResourceRequirements, error) {
+	limits, err := parseResourceList(cp.Spec.ResourceLimits)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid resource limit: %v", err)
+	}
+	requests, err := parseResourceList(cp.Spec.ResourceRequests)
+	if err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid resource request: %v", err)
+	}
+	if limitRequestRatio > 0 {
+		limits = deriveResourceList(limits, requests, limitRequestRatio)
+		requests = deriveResourceList(requests, limits, 1/limitRequestRatio)
+	}
+	return corev1.ResourceRequirements{Limits: limits, Requests: requests}, nil
+}
+
+// deriveResourceList completes the list with the resources of from it misses, scaled by the given ratio.
+func deriveResourceList(list, from corev1.ResourceList, ratio float64) corev1.ResourceList {
+	for name, quantity := range from {
+		if _, ok := list[name]; ok {
+			continue
+		}
+		if list == nil {
+			list = corev1.ResourceList{}
+		}
+		list[name] = *resource.NewMilliQuantity(int64(float64(quantity.MilliValue())*ratio), quantity.Format)
+	}
+	return list
+}
+
+// parseNamespaces parses a comma separated list of namespaces, ignoring the empty entries.
+func parseNamespaces(value string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(value, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// parseLimitRequestRatio parses the value of limitRequestRatioEnvVar, 0 when it is not set.
+func parseLimitRequestRatio(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio < 1 {
+		return 0, fmt.Errorf("%s %q must be a number greater than or equal to 1", limitRequestRatioEnvVar, value)
+	}
+	return ratio, nil
+}
+
+// parseResourceList parses the quantities of the given resources, e.g. {"memory": "512Mi"}.
+func parseResourceList(quantities map[string]string) (corev1.ResourceList, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+	list := corev1.ResourceList{}
+	for _, name := range sortedKeys(quantities) {
+		quantity, err := resource.ParseQuantity(quantities[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s %q is not a valid quantity", name, quantities[name])
+		}
+		list[corev1.ResourceName(name)] = quantity
+	}
+	return list, nil
+}
+
+// validateContainerResources checks that the resource limits and requests of the component are valid quantities.
+func validateContainerResources(cp *devconsoleapi.Component) error {
+	if _, err := newContainerResources(cp, 0); err != nil {
+		return withReason("InvalidResources", newTerminalError(err))
+	}
+	return nil
+}
+
+// imageTag matches the tags of the Docker images.
+var imageTag = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+// validateBuildTypeVersion checks that the build type version of the component is a valid tag of one of the images
+// of buildTypeImages, whose repositories are known unlike the ones of the ImageStreams of the builder namespaces.
+func validateBuildTypeVersion(cp *devconsoleapi.Component) error {
+	var err error
+	if _, ok := buildTypeImages[cp.Spec.BuildType]; !ok {
+		err = fmt.Errorf("build type %q cannot be built with version %s, supported: %v", cp.Spec.BuildType, cp.Spec.BuildTypeVersion, supportedBuildTypes())
+	} else if !imageTag.MatchString(cp.Spec.BuildTypeVersion) {
+		err = fmt.Errorf("invalid build type version %q", cp.Spec.BuildTypeVersion)
+	}
+	if err != nil {
+		requestLogger(cp).Error(err, "** Invalid build type version **")
+		return withReason("InvalidBuildTypeVersion", newTerminalError(err))
+	}
+	return nil
+}
+
+// validateVolumes checks that the volumes of the component have distinct names, absolute mount paths and valid sizes.
+func validateVolumes(cp *devconsoleapi.Component) error {
+	names := map[string]bool{}
+	for _, volume := range cp.Spec.Volumes {
+		var err error
+		switch {
+		case volume.Name == "":
+			err = fmt.Errorf("a volume has no name")
+		case names[volume.Name]:
+			err = fmt.Errorf("volume %s is declared more than once", volume.Name)
+		case !strings.HasPrefix(volume.MountPath, "/"):
+			err = fmt.Errorf("mount path %q of volume %s must be absolute", volume.MountPath, volume.Name)
+		}
+		if err == nil && volume.Size != "" {
+			if _, parseErr := resource.ParseQuantity(volume.Size); parseErr != nil {
+				err = fmt.Errorf("invalid size %q of volume %s: %v", volume.Size, volume.Name, parseErr)
+			}
+		}
+		if err != nil {
+			return withReason("InvalidVolume", newTerminalError(err))
+		}
+		names[volume.Name] = true
+	}
+	return nil
+}
+
+// validateReplicas checks that the component does not ask for a negative number of replicas.
+func validateReplicas(cp *devconsoleapi.Component) error {
+	if cp.Spec.Replicas < 0 {
+		err := fmt.Errorf("replicas %d must not be negative", cp.Spec.Replicas)
+		return withReason("InvalidReplicas", newTerminalError(err))
+	}
+	if autoscalingEnabled(cp) && cp.Spec.MinReplicas > cp.Spec.MaxReplicas {
+		err := fmt.Errorf("minReplicas %d must not exceed maxReplicas %d", cp.Spec.MinReplicas, cp.Spec.MaxReplicas)
+		return withReason("InvalidReplicas", newTerminalError(err))
+	}
+	return nil
+}
+
+const (
+	// minPort and maxPort bound the ports of the components.
+	minPort = 1
+	maxPort = 65535
+)
+
+// validatePorts checks that the ports of the component are in range and exposed once, before any of its resources
+// is created.
+func validatePorts(cp *devconsoleapi.Component) error {
+	ports := cp.Spec.Ports
+	if cp.Spec.Port != 0 {
+		ports = append([]int32{cp.Spec.Port}, ports...)
+	}
+	for _, port := range ports {
+		if port < minPort || port > maxPort {
+			err := fmt.Errorf("port %d is out of range [%d-%d]", port, minPort, maxPort)
+			return withReason("InvalidPort", newTerminalError(err))
+		}
+	}
+	seen := make(map[int32]bool, len(cp.Spec.Ports))
+	for _, port := range cp.Spec.Ports {
+		if seen[port] {
+			err := fmt.Errorf("port %d is exposed more than once", port)
+			return withReason("InvalidPort", newTerminalError(err))
+		}
+		seen[port] = true
+	}
+	return nil
+}
+
+// validatePrebuiltImage checks that a component which is not built gives the image to deploy.
+func validatePrebuiltImage(cp *devconsoleapi.Component) error {
+	if cp.Spec.Image != "" {
+		return nil
+	}
+	if cp.Spec.SkipOutputImageStream {
+		return newTerminalError(fmt.Errorf("an image must be provided when the output ImageStream is skipped"))
+	}
+	err := fmt.Errorf("an image must be provided with the %s build type", buildTypeNone)
+	return withReason("MissingImage", newTerminalError(err))
+}
+
+// validateBuildStrategy checks that the component is built with a supported build strategy.
+func validateBuildStrategy(cp *devconsoleapi.Component) error {
+	switch cp.Spec.BuildStrategy {
+	case "", buildStrategySource, buildStrategyDocker, buildStrategyAuto:
+		return nil
+	}
+	err := fmt.Errorf("unsupported build strategy %q, supported: %v", cp.Spec.BuildStrategy, []string{buildStrategySource, buildStrategyDocker, buildStrategyAuto})
+	return withReason("UnsupportedBuildStrategy", newTerminalError(err))
+}
+
+// buildStrategy returns the strategy the component is built with, the one detected for the auto strategy.
+func buildStrategy(cp *devconsoleapi.Component) string {
+	if cp.Spec.BuildStrategy == buildStrategyAuto {
+		return cp.Status.BuildStrategy
+	}
+	return cp.Spec.BuildStrategy
+}
+
+// checkOwnership reports an existing resource named after the component which belongs to another object, e.g. the
+// output ImageStream a component of another namespace pushes to the same shared namespace, rather than silently
+// overwriting it. Resources without owner are adopted.
+func checkOwnership(cp *devconsoleapi.Component, obj metav1.Object, kind string) error {
+	var owner string
+	if controller := metav1.GetControllerOf(obj); controller != nil && controller.UID != cp.UID {
+		owner = fmt.Sprintf("%s %s", controller.Kind, controller.Name)
+	} else if annotated := obj.GetAnnotations()[ownerAnnotation]; annotated != "" && annotated != cp.Namespace+"/"+cp.Name {
+		owner = fmt.Sprintf("Component %s", annotated)
+	}
+	if owner == "" {
+		return nil
+	}
+	err := fmt.Errorf("%s %s/%s already belongs to %s, rename the component to avoid the collision", kind, obj.GetNamespace(), obj.GetName(), owner)
+	return withReason("NameCollision", newTerminalError(err))
+}
+
+// scpLikeGitURL matches the scp-like syntax of the git SSH URLs, e.g. git@github.com:org/repo.git.
+var scpLikeGitURL = regexp.MustCompile(`^[\w.-]+@[\w.-]+:.+$`)
+
+// validateGitURL checks that the URL of the git source of the component is an http(s), git or ssh URL, so that a
+// malformed URL is reported rather than producing builds failing to clone. A git source without URL is left as is.
+func validateGitURL(gitSource *devconsoleapi.GitSource) error {
+	uri := gitSource.Spec.URL
+	if uri == "" || scpLikeGitURL.MatchString(uri) {
+		return nil
+	}
+	if parsed, err := url.Parse(uri); err == nil && parsed.Host != "" {
+		switch parsed.Scheme {
+		case "http", "https", "git", "ssh":
+			return nil
+		}
+	}
+	err := fmt.Errorf("invalid URL %q of GitSource %s, expected an http(s), git or ssh URL", uri, gitSource.Name)
+	return withReason("InvalidGitURL", newTerminalError(err))
+}
+
+// validateDeployStrategy checks that the component is deployed with a supported strategy.
+func validateDeployStrategy(cp *devconsoleapi.Component) error {
+	switch cp.Spec.DeployStrategy {
+	case "", deployStrategyRecreate, deployStrategyRolling:
+		return nil
+	}
+	err := fmt.Errorf("unsupported deploy strategy %q, supported: %v", cp.Spec.DeployStrategy, []string{deployStrategyRecreate, deployStrategyRolling})
+	return withReason("UnsupportedDeployStrategy", newTerminalError(err))
+}
+
+// validateImageStreamUpdatePolicy checks that the tags of the output ImageStream are reconciled with a supported
+// policy.
+func validateImageStreamUpdatePolicy(cp *devconsoleapi.Component) error {
+	switch cp.Spec.ImageStreamUpdatePolicy {
+	case "", imageStreamUpdatePolicyMerge, imageStreamUpdatePolicyReplace:
+		return nil
+	}
+	err := fmt.Errorf("unsupported ImageStream update policy %q, supported: %v", cp.Spec.ImageStreamUpdatePolicy, []string{imageStreamUpdatePolicyMerge, imageStreamUpdatePolicyReplace})
+	return withReason("UnsupportedImageStreamUpdatePolicy", newTerminalError(err))
+}
+
+// readinessSummary returns a one-liner summarizing the state of the component from its build, the pods of its
+// DeploymentConfig and its route, e.g. Build Complete, 3/3 pods ready, exposed at https://app.example.com.
+func readinessSummary(built, imageResolved bool, dc *appsv1.DeploymentConfig, route *routev1.Route) string {
+	var parts []string
+	if built {
+		if imageResolved {
+			parts = append(parts, "Build Complete")
+		} else {
+			parts = append(parts, "Waiting for build")
+		}
+	}
+	if dc != nil {
+		parts = append(parts, fmt.Sprintf("%d/%d pods ready", dc.Status.ReadyReplicas, dc.Spec.Replicas))
+	}
+	if route != nil && route.Spec.Host != "" {
+		scheme := "http"
+		if route.Spec.TLS != nil {
+			scheme = "https"
+		}
+		parts = append(parts, fmt.Sprintf("exposed at %s://%s", scheme, route.Spec.Host))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// configHash returns a hash of the configuration of the component's container, which changes whenever one of its
+// environment variables, sources or volumes does.
+func configHash(cp *devconsoleapi.Component) string {
+	config, _ := json.Marshal(struct {
+		Env     []corev1.EnvVar            `json:"env,omitempty"`
+		EnvFrom []corev1.EnvFromSource     `json:"envFrom,omitempty"`
+		Volumes []devconsoleapi.VolumeSpec `json:"volumes,omitempty"`
+	}{cp.Spec.Env, cp.Spec.EnvFrom, cp.Spec.Volumes})
+	sum := sha256.Sum256(config)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,116 @@
+package election
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var log = logf.Log.WithName("election")
+
+// Options tunes the lease based leader election of the operator.
+type Options struct {
+	// Enabled switches from the default leader-for-life lock to a lease based election, whose timing can be tuned.
+	Enabled bool
+	// ID is the name of the ConfigMap holding the lease.
+	ID string
+	// LeaseDuration is how long non-leader candidates wait before trying to acquire an expired lease.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long the leader keeps retrying to renew its lease before giving it up.
+	RenewDeadline time.Duration
+	// RetryPeriod is how long the candidates wait between two attempts.
+	RetryPeriod time.Duration
+}
+
+// DefaultOptions returns the options used by the controller-runtime manager.
+func DefaultOptions() Options {
+	return Options{
+		ID:            "devconsole-operator-lease",
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+	}
+}
+
+// AddFlags registers the command line flags tuning the leader election.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "leader-elect-lease", o.Enabled, "use a lease based leader election instead of a leader-for-life lock")
+	fs.StringVar(&o.ID, "leader-elect-id", o.ID, "name of the ConfigMap holding the leader election lease")
+	fs.DurationVar(&o.LeaseDuration, "leader-elect-lease-duration", o.LeaseDuration, "how long candidates wait before acquiring an expired lease")
+	fs.DurationVar(&o.RenewDeadline, "leader-elect-renew-deadline", o.RenewDeadline, "how long the leader retries renewing its lease before giving it up")
+	fs.DurationVar(&o.RetryPeriod, "leader-elect-retry-period", o.RetryPeriod, "how long candidates wait between two attempts")
+}
+
+// NewConfig returns the configuration of the leader election with the given lock, tuned by the options.
+func NewConfig(o Options, lock resourcelock.Interface, callbacks leaderelection.LeaderCallbacks) (leaderelection.LeaderElectionConfig, error) {
+	if o.LeaseDuration <= o.RenewDeadline {
+		return leaderelection.LeaderElectionConfig{}, fmt.Errorf("lease duration %s must be greater than renew deadline %s", o.LeaseDuration, o.RenewDeadline)
+	}
+	if o.RenewDeadline <= time.Duration(leaderelection.JitterFactor*float64(o.RetryPeriod)) {
+		return leaderelection.LeaderElectionConfig{}, fmt.Errorf("renew deadline %s must be greater than %v times the retry period %s", o.RenewDeadline, leaderelection.JitterFactor, o.RetryPeriod)
+	}
+	return leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: o.LeaseDuration,
+		RenewDeadline: o.RenewDeadline,
+		RetryPeriod:   o.RetryPeriod,
+		Callbacks:     callbacks,
+	}, nil
+}
+
+// Become blocks until this instance of the operator holds the lease in the given namespace. Losing the lease later
+// on makes the operator exit, so that it is restarted as a candidate.
+func Become(ctx context.Context, cfg *rest.Config, namespace string, o Options) error {
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+		identity = hostname
+	}
+	client, err := corev1client.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	lock, err := resourcelock.New(resourcelock.ConfigMapsResourceLock, namespace, o.ID, client, resourcelock.ResourceLockConfig{
+		Identity: identity,
+	})
+	if err != nil {
+		return err
+	}
+	leading := make(chan struct{})
+	config, err := NewConfig(o, lock, leaderelection.LeaderCallbacks{
+		OnStartedLeading: func(context.Context) {
+			close(leading)
+		},
+		OnStoppedLeading: func() {
+			log.Info("Leader election lost, exiting.")
+			os.Exit(1)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	elector, err := leaderelection.NewLeaderElector(config)
+	if err != nil {
+		return err
+	}
+	log.Info("Trying to become the leader.", "Identity", identity, "LeaseDuration", o.LeaseDuration.String())
+	go elector.Run(ctx)
+	select {
+	case <-leading:
+		log.Info("Became the leader.")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+package election
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Run("with tuned durations", func(t *testing.T) {
+		//given
+		options := DefaultOptions()
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		options.AddFlags(fs)
+		err := fs.Parse([]string{
+			"--leader-elect-lease",
+			"--leader-elect-lease-duration=60s",
+			"--leader-elect-renew-deadline=40s",
+			"--leader-elect-retry-period=5s",
+		})
+		require.NoError(t, err, "flags should be parsed")
+		lock := &resourcelock.ConfigMapLock{}
+
+		//when
+		config, err := NewConfig(options, lock, leaderelection.LeaderCallbacks{})
+
+		//then
+		require.NoError(t, err, "options should be valid")
+		require.True(t, options.Enabled, "lease based election should be enabled")
+		require.Equal(t, 60*time.Second, config.LeaseDuration, "lease duration should be applied")
+		require.Equal(t, 40*time.Second, config.RenewDeadline, "renew deadline should be applied")
+		require.Equal(t, 5*time.Second, config.RetryPeriod, "retry period should be applied")
+		require.Equal(t, lock, config.Lock, "lock should be used")
+	})
+
+	t.Run("with default durations", func(t *testing.T) {
+		//when
+		config, err := NewConfig(DefaultOptions(), &resourcelock.ConfigMapLock{}, leaderelection.LeaderCallbacks{})
+
+		//then
+		require.NoError(t, err, "default options should be valid")
+		require.Equal(t, 15*time.Second, config.LeaseDuration)
+		require.Equal(t, 10*time.Second, config.RenewDeadline)
+		require.Equal(t, 2*time.Second, config.RetryPeriod)
+	})
+
+	t.Run("with a renew deadline longer than the lease", func(t *testing.T) {
+		//given
+		options := DefaultOptions()
+		options.RenewDeadline = 20 * time.Second
+
+		//when
+		_, err := NewConfig(options, &resourcelock.ConfigMapLock{}, leaderelection.LeaderCallbacks{})
+
+		//then
+		require.Error(t, err, "renew deadline must be shorter than the lease")
+	})
+
+	t.Run("with a retry period too close to the renew deadline", func(t *testing.T) {
+		//given
+		options := DefaultOptions()
+		options.RetryPeriod = 9 * time.Second
+
+		//when
+		_, err := NewConfig(options, &resourcelock.ConfigMapLock{}, leaderelection.LeaderCallbacks{})
+
+		//then
+		require.Error(t, err, "retry period must leave room for jitter before the renew deadline")
+	})
+}